@@ -0,0 +1,370 @@
+package woocommerce
+
+import (
+	"fmt"
+)
+
+const (
+	analyticsReportsBasePath = "reports"
+)
+
+// AnalyticsService is an interface for interfacing with the WooCommerce Analytics endpoints
+// (wc-analytics namespace), which supersede the legacy /reports endpoints.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#reports
+type AnalyticsService interface {
+	RevenueStats(options interface{}) (*RevenueStats, error)
+	OrdersStats(options interface{}) (*OrdersStats, error)
+	Products(options interface{}) ([]AnalyticsProduct, error)
+	Variations(options interface{}) ([]AnalyticsVariation, error)
+	Categories(options interface{}) ([]AnalyticsCategory, error)
+	Coupons(options interface{}) ([]AnalyticsCoupon, error)
+	Taxes(options interface{}) ([]AnalyticsTax, error)
+	Downloads(options interface{}) ([]AnalyticsDownload, error)
+	Stock(options interface{}) ([]AnalyticsStock, error)
+	Customers(options interface{}) ([]AnalyticsCustomer, error)
+}
+
+// AnalyticsServiceOp handles communication with the wc-analytics related methods of the WooCommerce API
+type AnalyticsServiceOp struct {
+	client *Client
+}
+
+// AnalyticsListOptions are the request params shared by the wc-analytics list reports.
+// parameters:
+// page	integer	Current page of the collection. Default is 1.
+// per_page	integer	Maximum number of items to be returned in result set. Default is 10.
+// after	string	Limit response to resources published after a given ISO8601 compliant date.
+// before	string	Limit response to resources published before a given ISO8601 compliant date.
+// order	string	Order sort attribute ascending or descending. Options: asc and desc. Default is desc.
+// orderby	string	Sort collection by object attribute, e.g. date, net_revenue, orders_count, items_sold.
+// match	string	Whether all or any of the filters must match. Options: all and any.
+// extended_info	boolean	Add additional piece of info about each item to the report.
+type AnalyticsListOptions struct {
+	Page         int    `url:"page,omitempty"`
+	PerPage      int    `url:"per_page,omitempty"`
+	After        Time   `url:"after,omitempty"`
+	Before       Time   `url:"before,omitempty"`
+	Order        string `url:"order,omitempty"`
+	Orderby      string `url:"orderby,omitempty"`
+	Match        string `url:"match,omitempty"`
+	ExtendedInfo bool   `url:"extended_info,omitempty"`
+}
+
+// AnalyticsStatsOptions are the request params of the wc-analytics stats reports.
+// parameters:
+// interval	string	Time interval to use for buckets in the returned data. Options: hour, day, week, month, quarter and year. Default is week.
+// segmentby	string	Segment the response by additional constraint. Options: product, category, variation, coupon and customer_type.
+// fields	array	Limit stats fields to the specified items.
+// force_cache_refresh	boolean	Force retrieval of fresh data instead of from the cache.
+type AnalyticsStatsOptions struct {
+	AnalyticsListOptions
+	Interval          string   `url:"interval,omitempty"`
+	Segmentby         string   `url:"segmentby,omitempty"`
+	Fields            []string `url:"fields,omitempty,brackets"`
+	ForceCacheRefresh bool     `url:"force_cache_refresh,omitempty"`
+}
+
+// AnalyticsInterval holds the date bounds shared by every stats interval.
+type AnalyticsInterval struct {
+	Interval     string `json:"interval,omitempty"`
+	DateStart    *Time  `json:"date_start,omitempty"`
+	DateStartGmt *Time  `json:"date_start_gmt,omitempty"`
+	DateEnd      *Time  `json:"date_end,omitempty"`
+	DateEndGmt   *Time  `json:"date_end_gmt,omitempty"`
+}
+
+// RevenueStats represents the response of the revenue stats report
+type RevenueStats struct {
+	Totals    RevenueTotals     `json:"totals"`
+	Intervals []RevenueInterval `json:"intervals,omitempty"`
+	Extra     Extra             `json:"-"`
+}
+
+// RevenueTotals are the revenue figures for the whole period, an interval or a segment
+type RevenueTotals struct {
+	OrdersCount  int              `json:"orders_count,omitempty"`
+	NumItemsSold int              `json:"num_items_sold,omitempty"`
+	GrossSales   Amount           `json:"gross_sales,omitempty"`
+	TotalSales   Amount           `json:"total_sales,omitempty"`
+	Coupons      Amount           `json:"coupons,omitempty"`
+	CouponsCount int              `json:"coupons_count,omitempty"`
+	Refunds      Amount           `json:"refunds,omitempty"`
+	Taxes        Amount           `json:"taxes,omitempty"`
+	Shipping     Amount           `json:"shipping,omitempty"`
+	NetRevenue   Amount           `json:"net_revenue,omitempty"`
+	Products     int              `json:"products,omitempty"`
+	Segments     []RevenueSegment `json:"segments,omitempty"`
+	Extra        Extra            `json:"-"`
+}
+
+// RevenueInterval is a single time bucket of the revenue stats report
+type RevenueInterval struct {
+	AnalyticsInterval
+	Subtotals RevenueTotals `json:"subtotals"`
+	Extra     Extra         `json:"-"`
+}
+
+// RevenueSegment is the revenue of a single segment, see AnalyticsStatsOptions.Segmentby
+type RevenueSegment struct {
+	SegmentID    interface{}   `json:"segment_id,omitempty"`
+	SegmentLabel string        `json:"segment_label,omitempty"`
+	Subtotals    RevenueTotals `json:"subtotals"`
+	Extra        Extra         `json:"-"`
+}
+
+// OrdersStats represents the response of the orders stats report
+type OrdersStats struct {
+	Totals    OrdersTotals     `json:"totals"`
+	Intervals []OrdersInterval `json:"intervals,omitempty"`
+	Extra     Extra            `json:"-"`
+}
+
+// OrdersTotals are the order figures for the whole period, an interval or a segment
+type OrdersTotals struct {
+	OrdersCount      int             `json:"orders_count,omitempty"`
+	NumItemsSold     int             `json:"num_items_sold,omitempty"`
+	AvgItemsPerOrder float64         `json:"avg_items_per_order,omitempty"`
+	AvgOrderValue    Amount          `json:"avg_order_value,omitempty"`
+	NetRevenue       Amount          `json:"net_revenue,omitempty"`
+	Coupons          Amount          `json:"coupons,omitempty"`
+	CouponsCount     int             `json:"coupons_count,omitempty"`
+	TotalCustomers   int             `json:"total_customers,omitempty"`
+	Products         int             `json:"products,omitempty"`
+	Segments         []OrdersSegment `json:"segments,omitempty"`
+	Extra            Extra           `json:"-"`
+}
+
+// OrdersInterval is a single time bucket of the orders stats report
+type OrdersInterval struct {
+	AnalyticsInterval
+	Subtotals OrdersTotals `json:"subtotals"`
+	Extra     Extra        `json:"-"`
+}
+
+// OrdersSegment is the order figures of a single segment, see AnalyticsStatsOptions.Segmentby
+type OrdersSegment struct {
+	SegmentID    interface{}  `json:"segment_id,omitempty"`
+	SegmentLabel string       `json:"segment_label,omitempty"`
+	Subtotals    OrdersTotals `json:"subtotals"`
+	Extra        Extra        `json:"-"`
+}
+
+// AnalyticsProduct represents a row of the products report
+type AnalyticsProduct struct {
+	ProductID    int64                    `json:"product_id,omitempty"`
+	ItemsSold    int                      `json:"items_sold,omitempty"`
+	NetRevenue   Amount                   `json:"net_revenue,omitempty"`
+	OrdersCount  int                      `json:"orders_count,omitempty"`
+	ExtendedInfo *AnalyticsProductDetails `json:"extended_info,omitempty"`
+	Extra        Extra                    `json:"-"`
+}
+
+// AnalyticsVariation represents a row of the variations report
+type AnalyticsVariation struct {
+	ProductID    int64                    `json:"product_id,omitempty"`
+	VariationID  int64                    `json:"variation_id,omitempty"`
+	ItemsSold    int                      `json:"items_sold,omitempty"`
+	NetRevenue   Amount                   `json:"net_revenue,omitempty"`
+	OrdersCount  int                      `json:"orders_count,omitempty"`
+	ExtendedInfo *AnalyticsProductDetails `json:"extended_info,omitempty"`
+	Extra        Extra                    `json:"-"`
+}
+
+// AnalyticsProductDetails is the extended_info of the products and variations reports
+type AnalyticsProductDetails struct {
+	Name           string      `json:"name,omitempty"`
+	Price          Amount      `json:"price,omitempty"`
+	Image          string      `json:"image,omitempty"`
+	Permalink      string      `json:"permalink,omitempty"`
+	StockStatus    string      `json:"stock_status,omitempty"`
+	StockQuantity  int         `json:"stock_quantity,omitempty"`
+	ManageStock    bool        `json:"manage_stock,omitempty"`
+	LowStockAmount int         `json:"low_stock_amount,omitempty"`
+	CategoryIds    []int64     `json:"category_ids,omitempty"`
+	Variations     []int64     `json:"variations,omitempty"`
+	Attributes     []Attribute `json:"attributes,omitempty"`
+	Sku            string      `json:"sku,omitempty"`
+	Extra          Extra       `json:"-"`
+}
+
+// AnalyticsCategory represents a row of the categories report
+type AnalyticsCategory struct {
+	CategoryID    int64  `json:"category_id,omitempty"`
+	ItemsSold     int    `json:"items_sold,omitempty"`
+	NetRevenue    Amount `json:"net_revenue,omitempty"`
+	OrdersCount   int    `json:"orders_count,omitempty"`
+	ProductsCount int    `json:"products_count,omitempty"`
+	ExtendedInfo  *struct {
+		Name string `json:"name,omitempty"`
+	} `json:"extended_info,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+// AnalyticsCoupon represents a row of the coupons report
+type AnalyticsCoupon struct {
+	CouponID     int64  `json:"coupon_id,omitempty"`
+	Amount       Amount `json:"amount,omitempty"`
+	OrdersCount  int    `json:"orders_count,omitempty"`
+	ExtendedInfo *struct {
+		Code           string `json:"code,omitempty"`
+		DateCreated    *Time  `json:"date_created,omitempty"`
+		DateCreatedGmt *Time  `json:"date_created_gmt,omitempty"`
+		DateExpires    *Time  `json:"date_expires,omitempty"`
+		DateExpiresGmt *Time  `json:"date_expires_gmt,omitempty"`
+		DiscountType   string `json:"discount_type,omitempty"`
+	} `json:"extended_info,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+// AnalyticsTax represents a row of the taxes report
+type AnalyticsTax struct {
+	TaxRateID   int64   `json:"tax_rate_id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	TaxRate     float64 `json:"tax_rate,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Priority    int     `json:"priority,omitempty"`
+	TotalTax    Amount  `json:"total_tax,omitempty"`
+	OrderTax    Amount  `json:"order_tax,omitempty"`
+	ShippingTax Amount  `json:"shipping_tax,omitempty"`
+	OrdersCount int     `json:"orders_count,omitempty"`
+	Extra       Extra   `json:"-"`
+}
+
+// AnalyticsDownload represents a row of the downloads report
+type AnalyticsDownload struct {
+	ID          int64  `json:"id,omitempty"`
+	ProductID   int64  `json:"product_id,omitempty"`
+	Date        *Time  `json:"date,omitempty"`
+	DateGmt     *Time  `json:"date_gmt,omitempty"`
+	DownloadID  string `json:"download_id,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	FilePath    string `json:"file_path,omitempty"`
+	OrderID     int64  `json:"order_id,omitempty"`
+	OrderNumber string `json:"order_number,omitempty"`
+	UserID      int64  `json:"user_id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	IpAddress   string `json:"ip_address,omitempty"`
+	Extra       Extra  `json:"-"`
+}
+
+// AnalyticsStock represents a row of the stock report
+type AnalyticsStock struct {
+	ID             int64  `json:"id,omitempty"`
+	ParentID       int64  `json:"parent_id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Sku            string `json:"sku,omitempty"`
+	StockStatus    string `json:"stock_status,omitempty"`
+	StockQuantity  int    `json:"stock_quantity,omitempty"`
+	ManageStock    bool   `json:"manage_stock,omitempty"`
+	LowStockAmount int    `json:"low_stock_amount,omitempty"`
+	Extra          Extra  `json:"-"`
+}
+
+// AnalyticsCustomer represents a row of the customers report
+type AnalyticsCustomer struct {
+	ID                int64  `json:"id,omitempty"`
+	UserID            int64  `json:"user_id,omitempty"`
+	Username          string `json:"username,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Email             string `json:"email,omitempty"`
+	Country           string `json:"country,omitempty"`
+	City              string `json:"city,omitempty"`
+	State             string `json:"state,omitempty"`
+	Postcode          string `json:"postcode,omitempty"`
+	DateRegistered    *Time  `json:"date_registered,omitempty"`
+	DateRegisteredGmt *Time  `json:"date_registered_gmt,omitempty"`
+	DateLastActive    *Time  `json:"date_last_active,omitempty"`
+	DateLastActiveGmt *Time  `json:"date_last_active_gmt,omitempty"`
+	DateLastOrder     *Time  `json:"date_last_order,omitempty"`
+	OrdersCount       int    `json:"orders_count,omitempty"`
+	TotalSpend        Amount `json:"total_spend,omitempty"`
+	AvgOrderValue     Amount `json:"avg_order_value,omitempty"`
+	Extra             Extra  `json:"-"`
+}
+
+// get performs a GET request against the wc-analytics namespace
+func (a *AnalyticsServiceOp) get(path string, resource, options interface{}) error {
+	_, err := a.client.createAndDoWithPrefix(analyticsPathPrefix, "GET", path, nil, options, resource)
+	return err
+}
+
+// RevenueStats retrieves the revenue stats report, totals and intervals
+func (a *AnalyticsServiceOp) RevenueStats(options interface{}) (*RevenueStats, error) {
+	path := fmt.Sprintf("%s/revenue/stats", analyticsReportsBasePath)
+	resource := new(RevenueStats)
+	err := a.get(path, resource, options)
+	return resource, err
+}
+
+// OrdersStats retrieves the orders stats report, totals and intervals
+func (a *AnalyticsServiceOp) OrdersStats(options interface{}) (*OrdersStats, error) {
+	path := fmt.Sprintf("%s/orders/stats", analyticsReportsBasePath)
+	resource := new(OrdersStats)
+	err := a.get(path, resource, options)
+	return resource, err
+}
+
+// Products retrieves the products report
+func (a *AnalyticsServiceOp) Products(options interface{}) ([]AnalyticsProduct, error) {
+	path := fmt.Sprintf("%s/products", analyticsReportsBasePath)
+	resource := make([]AnalyticsProduct, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Variations retrieves the variations report
+func (a *AnalyticsServiceOp) Variations(options interface{}) ([]AnalyticsVariation, error) {
+	path := fmt.Sprintf("%s/variations", analyticsReportsBasePath)
+	resource := make([]AnalyticsVariation, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Categories retrieves the categories report
+func (a *AnalyticsServiceOp) Categories(options interface{}) ([]AnalyticsCategory, error) {
+	path := fmt.Sprintf("%s/categories", analyticsReportsBasePath)
+	resource := make([]AnalyticsCategory, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Coupons retrieves the coupons report
+func (a *AnalyticsServiceOp) Coupons(options interface{}) ([]AnalyticsCoupon, error) {
+	path := fmt.Sprintf("%s/coupons", analyticsReportsBasePath)
+	resource := make([]AnalyticsCoupon, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Taxes retrieves the taxes report
+func (a *AnalyticsServiceOp) Taxes(options interface{}) ([]AnalyticsTax, error) {
+	path := fmt.Sprintf("%s/taxes", analyticsReportsBasePath)
+	resource := make([]AnalyticsTax, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Downloads retrieves the downloads report
+func (a *AnalyticsServiceOp) Downloads(options interface{}) ([]AnalyticsDownload, error) {
+	path := fmt.Sprintf("%s/downloads", analyticsReportsBasePath)
+	resource := make([]AnalyticsDownload, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Stock retrieves the stock report
+func (a *AnalyticsServiceOp) Stock(options interface{}) ([]AnalyticsStock, error) {
+	path := fmt.Sprintf("%s/stock", analyticsReportsBasePath)
+	resource := make([]AnalyticsStock, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
+
+// Customers retrieves the customers report
+func (a *AnalyticsServiceOp) Customers(options interface{}) ([]AnalyticsCustomer, error) {
+	path := fmt.Sprintf("%s/customers", analyticsReportsBasePath)
+	resource := make([]AnalyticsCustomer, 0)
+	err := a.get(path, &resource, options)
+	return resource, err
+}
@@ -0,0 +1,46 @@
+package woocommerce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAnalyticsServiceOp_RevenueStats(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wp-json/wc-analytics/reports/revenue/stats" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("interval"); got != "month" {
+			t.Errorf("interval = %q, want month", got)
+		}
+		if got := r.URL.Query()["fields[]"]; len(got) != 2 {
+			t.Errorf("fields[] = %v, want 2 values", got)
+		}
+		w.Write([]byte(`{
+			"totals": {"orders_count": 3, "net_revenue": 120.5, "segments": [
+				{"segment_id": 10, "segment_label": "Hoodie", "subtotals": {"orders_count": 1, "net_revenue": 40}}
+			]},
+			"intervals": [
+				{"interval": "2024-01", "date_start": "2024-01-01 00:00:00", "date_end_gmt": "2024-01-31 23:59:59",
+				 "subtotals": {"orders_count": 3, "net_revenue": 120.5}}
+			]
+		}`))
+	}))
+
+	stats, err := c.Analytics.RevenueStats(AnalyticsStatsOptions{
+		Interval: "month",
+		Fields:   []string{"orders_count", "net_revenue"},
+	})
+	if err != nil {
+		t.Fatalf("revenue stats fail: %v", err)
+	}
+	if stats.Totals.OrdersCount != 3 || stats.Totals.NetRevenue != "120.5" {
+		t.Errorf("unexpected totals: %+v", stats.Totals)
+	}
+	if len(stats.Totals.Segments) != 1 || stats.Totals.Segments[0].SegmentLabel != "Hoodie" {
+		t.Errorf("unexpected segments: %+v", stats.Totals.Segments)
+	}
+	if len(stats.Intervals) != 1 || stats.Intervals[0].Interval != "2024-01" {
+		t.Errorf("unexpected intervals: %+v", stats.Intervals)
+	}
+}
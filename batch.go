@@ -0,0 +1,286 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// maxBatchSize is the default WooCommerce limit of objects per batch request
+	maxBatchSize = 100
+
+	defaultBatchConcurrency = 2
+)
+
+// BatchRequest creates, updates and deletes resources of type T in one batch. The BatchOption
+// types of the services convert to it, e.g. BatchRequest[Order](data).
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-orders
+type BatchRequest[T any] struct {
+	Create []T     `json:"create,omitempty"`
+	Update []T     `json:"update,omitempty"`
+	Delete []int64 `json:"delete,omitempty"`
+}
+
+// Len returns the number of objects of the batch
+func (r BatchRequest[T]) Len() int {
+	return len(r.Create) + len(r.Update) + len(r.Delete)
+}
+
+// Split splits the batch in requests of at most size objects, keeping their order
+func (r BatchRequest[T]) Split(size int) []BatchRequest[T] {
+	if size <= 0 {
+		size = maxBatchSize
+	}
+	chunks := make([]BatchRequest[T], 0, (r.Len()+size-1)/size)
+	current := BatchRequest[T]{}
+	n := 0
+	flush := func() {
+		if n > 0 {
+			chunks = append(chunks, current)
+			current = BatchRequest[T]{}
+			n = 0
+		}
+	}
+	for _, v := range r.Create {
+		current.Create = append(current.Create, v)
+		if n++; n == size {
+			flush()
+		}
+	}
+	for _, v := range r.Update {
+		current.Update = append(current.Update, v)
+		if n++; n == size {
+			flush()
+		}
+	}
+	for _, id := range r.Delete {
+		current.Delete = append(current.Delete, id)
+		if n++; n == size {
+			flush()
+		}
+	}
+	flush()
+	return chunks
+}
+
+// BatchOption configures the BatchAll methods. ChunkSize is the number of objects per request,
+// 100 by default, the limit of WooCommerce unless raised with the woocommerce_rest_batch_items_limit
+// filter. Concurrency is the number of requests sent at once, 2 by default.
+type BatchOption struct {
+	ChunkSize   int
+	Concurrency int
+}
+
+// BatchItemError is the error WooCommerce returns for an object of a batch it failed to
+// process, e.g. woocommerce_rest_shop_order_invalid_id
+type BatchItemError struct {
+	Code    string
+	Message string
+	Status  int
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// UnmarshalJSON reads the status from the error's data
+func (e *BatchItemError) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Status int `json:"status"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*e = BatchItemError{Code: raw.Code, Message: raw.Message, Status: raw.Data.Status}
+	return nil
+}
+
+// BatchItem is the outcome of an object of a batch. ID is the ID of the resource, 0 for a
+// failed create. Err is set when WooCommerce rejected the object or its request failed.
+type BatchItem[T any] struct {
+	ID       int64
+	Resource *T
+	Err      *BatchItemError
+}
+
+// BatchResult holds the outcome of every object of a batch, in the order of the request
+type BatchResult[T any] struct {
+	Create []BatchItem[T]
+	Update []BatchItem[T]
+	Delete []BatchItem[T]
+}
+
+// Failed returns the items that failed
+func (r *BatchResult[T]) Failed() []BatchItem[T] {
+	failed := make([]BatchItem[T], 0)
+	for _, items := range [][]BatchItem[T]{r.Create, r.Update, r.Delete} {
+		for _, item := range items {
+			if item.Err != nil {
+				failed = append(failed, item)
+			}
+		}
+	}
+	return failed
+}
+
+// Err returns a BatchError when some items failed, nil otherwise
+func (r *BatchResult[T]) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BatchError{Failed: len(failed), First: failed[0].Err}
+}
+
+// BatchError reports the items of a batch that failed
+type BatchError struct {
+	Failed int
+	First  *BatchItemError
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("woocommerce: %d batch items failed, first: %s", e.Failed, e.First)
+}
+
+// batchItemResponse is an object of a batch response, a resource or an error
+type batchItemResponse struct {
+	ID    int64           `json:"id"`
+	Error *BatchItemError `json:"error"`
+}
+
+// batchResponse is the raw response of a batch request
+type batchResponse struct {
+	Create []json.RawMessage `json:"create"`
+	Update []json.RawMessage `json:"update"`
+	Delete []json.RawMessage `json:"delete"`
+}
+
+// executeBatch posts the batch to path, in the REST namespace prefix, in chunks with bounded
+// concurrency. Failed requests fail the items of their chunk, the returned error is the first
+// of them.
+func executeBatch[T any](c *Client, prefix, path string, data BatchRequest[T], options *BatchOption) (*BatchResult[T], error) {
+	option := BatchOption{ChunkSize: maxBatchSize, Concurrency: defaultBatchConcurrency}
+	if options != nil {
+		if options.ChunkSize > 0 {
+			option.ChunkSize = options.ChunkSize
+		}
+		if options.Concurrency > 0 {
+			option.Concurrency = options.Concurrency
+		}
+	}
+
+	chunks := data.Split(option.ChunkSize)
+	results := make([]*BatchResult[T], len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, option.Concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk BatchRequest[T]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			response := new(batchResponse)
+			if _, errs[i] = c.createAndDoWithPrefix(prefix, "POST", path, chunk, nil, response); errs[i] != nil {
+				results[i] = failedBatch(chunk, errs[i])
+				return
+			}
+			results[i], errs[i] = decodeBatch(chunk, response)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	result := new(BatchResult[T])
+	for _, r := range results {
+		result.Create = append(result.Create, r.Create...)
+		result.Update = append(result.Update, r.Update...)
+		result.Delete = append(result.Delete, r.Delete...)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// decodeBatch matches the objects of a batch response with those of the request
+func decodeBatch[T any](chunk BatchRequest[T], response *batchResponse) (*BatchResult[T], error) {
+	result := new(BatchResult[T])
+	var err error
+	if result.Create, err = decodeBatchItems[T](response.Create, len(chunk.Create), func(i int) int64 { return 0 }); err != nil {
+		return failedBatch(chunk, err), err
+	}
+	if result.Update, err = decodeBatchItems[T](response.Update, len(chunk.Update), func(i int) int64 { return batchObjectID(chunk.Update[i]) }); err != nil {
+		return failedBatch(chunk, err), err
+	}
+	if result.Delete, err = decodeBatchItems[T](response.Delete, len(chunk.Delete), func(i int) int64 { return chunk.Delete[i] }); err != nil {
+		return failedBatch(chunk, err), err
+	}
+	return result, nil
+}
+
+func decodeBatchItems[T any](raw []json.RawMessage, n int, id func(i int) int64) ([]BatchItem[T], error) {
+	if len(raw) != n {
+		return nil, ResponseDecodingError{Message: fmt.Sprintf("batch response has %d items, want %d", len(raw), n)}
+	}
+	items := make([]BatchItem[T], n)
+	for i, data := range raw {
+		var response batchItemResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, ResponseDecodingError{Body: data, Message: err.Error()}
+		}
+		items[i].ID = id(i)
+		if response.Error != nil {
+			items[i].Err = response.Error
+			continue
+		}
+		resource := new(T)
+		if err := json.Unmarshal(data, resource); err != nil {
+			return nil, ResponseDecodingError{Body: data, Message: err.Error()}
+		}
+		items[i].Resource = resource
+		if response.ID != 0 {
+			items[i].ID = response.ID
+		}
+	}
+	return items, nil
+}
+
+// failedBatch fails every object of a chunk with the error of its request
+func failedBatch[T any](chunk BatchRequest[T], err error) *BatchResult[T] {
+	itemErr := &BatchItemError{Message: err.Error()}
+	var responseErr ResponseError
+	var rateLimitErr RateLimitError
+	switch {
+	case errors.As(err, &rateLimitErr):
+		itemErr.Code, itemErr.Status = rateLimitErr.Code, rateLimitErr.Status
+	case errors.As(err, &responseErr):
+		itemErr.Code, itemErr.Status = responseErr.Code, responseErr.Status
+	}
+
+	result := new(BatchResult[T])
+	for range chunk.Create {
+		result.Create = append(result.Create, BatchItem[T]{Err: itemErr})
+	}
+	for _, v := range chunk.Update {
+		result.Update = append(result.Update, BatchItem[T]{ID: batchObjectID(v), Err: itemErr})
+	}
+	for _, id := range chunk.Delete {
+		result.Delete = append(result.Delete, BatchItem[T]{ID: id, Err: itemErr})
+	}
+	return result
+}
+
+// batchObjectID returns the id property of an object to update
+func batchObjectID(v interface{}) int64 {
+	id, _ := itemID(reflect.ValueOf(v))
+	return id
+}
@@ -0,0 +1,128 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchRequest_Split(t *testing.T) {
+	data := BatchRequest[Webhook]{
+		Create: make([]Webhook, 150),
+		Update: make([]Webhook, 60),
+		Delete: make([]int64, 5),
+	}
+	chunks := data.Split(maxBatchSize)
+	if len(chunks) != 3 {
+		t.Fatalf("%d chunks, want 3", len(chunks))
+	}
+	if chunks[0].Len() != 100 || chunks[1].Len() != 100 || chunks[2].Len() != 15 {
+		t.Errorf("chunk sizes %d, %d, %d", chunks[0].Len(), chunks[1].Len(), chunks[2].Len())
+	}
+	if len(chunks[1].Create) != 50 || len(chunks[1].Update) != 50 || len(chunks[2].Delete) != 5 {
+		t.Errorf("second chunk = %d create, %d update", len(chunks[1].Create), len(chunks[1].Update))
+	}
+	if len(BatchRequest[Webhook]{}.Split(0)) != 0 {
+		t.Error("empty batch has chunks")
+	}
+}
+
+func TestOrderServiceOp_BatchAll(t *testing.T) {
+	var requests int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var data BatchRequest[Order]
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			t.Error(err)
+		}
+		if data.Len() > 2 {
+			t.Errorf("chunk of %d objects", data.Len())
+		}
+		response := map[string][]interface{}{}
+		for _, o := range data.Update {
+			if o.ID == 13 {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"code":"internal_error","message":"boom","data":{"status":500}}`)
+				return
+			}
+			response["update"] = append(response["update"], map[string]interface{}{"id": o.ID, "status": o.Status})
+		}
+		for _, id := range data.Delete {
+			if id == 99 {
+				response["delete"] = append(response["delete"], map[string]interface{}{
+					"id":    0,
+					"error": map[string]interface{}{"code": "woocommerce_rest_shop_order_invalid_id", "message": "Invalid ID.", "data": map[string]int{"status": 404}},
+				})
+				continue
+			}
+			response["delete"] = append(response["delete"], map[string]interface{}{"id": id})
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	data := OrderBatchOption{
+		Update: []Order{{ID: 10, Status: OrderStatusCompleted}, {ID: 11, Status: OrderStatusCompleted}, {ID: 12}, {ID: 13}},
+		Delete: []int64{98, 99},
+	}
+	result, err := c.Order.BatchAll(data, &BatchOption{ChunkSize: 2, Concurrency: 2})
+	var responseErr ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Status != http.StatusInternalServerError {
+		t.Fatalf("BatchAll() error = %v, want the failed request's", err)
+	}
+	if requests != 3 {
+		t.Errorf("%d requests, want 3", requests)
+	}
+
+	if len(result.Update) != 4 || len(result.Delete) != 2 {
+		t.Fatalf("result = %d updates, %d deletes", len(result.Update), len(result.Delete))
+	}
+	if item := result.Update[1]; item.ID != 11 || item.Err != nil || item.Resource.Status != OrderStatusCompleted {
+		t.Errorf("update 11 = %+v", item)
+	}
+	if item := result.Update[2]; item.ID != 12 || item.Err == nil || item.Err.Status != http.StatusInternalServerError {
+		t.Errorf("update 12 of the failed chunk = %+v", item)
+	}
+	if item := result.Delete[1]; item.ID != 99 || item.Err == nil || item.Err.Code != "woocommerce_rest_shop_order_invalid_id" || item.Err.Status != 404 {
+		t.Errorf("delete 99 = %+v", item)
+	}
+	if failed := result.Failed(); len(failed) != 3 {
+		t.Errorf("%d failed items, want 3", len(failed))
+	}
+	var batchErr *BatchError
+	if !errors.As(result.Err(), &batchErr) || batchErr.Failed != 3 {
+		t.Errorf("Err() = %v", result.Err())
+	}
+}
+
+// TestOrderServiceOp_BatchAllHTTP sends concurrent batches to a store without https, whose
+// requests are signed with OAuth1, run it with -race
+func TestOrderServiceOp_BatchAllHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "OAuth ") || r.URL.Query().Has("consumer_secret") {
+			t.Errorf("request not signed with OAuth1: %s %v", r.URL, r.Header)
+		}
+		var data BatchRequest[Order]
+		json.NewDecoder(r.Body).Decode(&data)
+		response := map[string][]Order{"update": data.Update}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer srv.Close()
+	c := NewClient(App{CustomerKey: customerKey, CustomerSecret: customerSecret}, srv.URL)
+
+	data := OrderBatchOption{}
+	for id := int64(1); id <= 8; id++ {
+		data.Update = append(data.Update, Order{ID: id, Status: OrderStatusCompleted})
+	}
+	result, err := c.Order.BatchAll(data, &BatchOption{ChunkSize: 1, Concurrency: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Update) != 8 || result.Update[7].ID != 8 {
+		t.Errorf("result = %+v", result.Update)
+	}
+}
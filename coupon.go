@@ -0,0 +1,109 @@
+package woocommerce
+
+const (
+	couponsBasePath = "coupons"
+)
+
+// CouponService is an interface for interfacing with the coupon endpoints of WooCommerce API
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#coupons
+type CouponService interface {
+	Create(coupon Coupon) (*Coupon, error)
+	Get(couponID int64, options interface{}) (*Coupon, error)
+	List(options interface{}) ([]Coupon, error)
+	ListWithPages(options interface{}) ([]Coupon, *Pagination, error)
+	Update(coupon *Coupon) (*Coupon, error)
+	Patch(couponID int64, patch Patch) (*Coupon, error)
+	Delete(couponID int64, options interface{}) (*Coupon, error)
+	BatchAll(data BatchRequest[Coupon], options *BatchOption) (*BatchResult[Coupon], error)
+}
+
+// CouponServiceOp handles communication with the coupon related methods of the WooCommerce API
+type CouponServiceOp struct {
+	client *Client
+}
+
+// CouponListOption lists the coupon list option request params
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#list-all-coupons
+type CouponListOption struct {
+	ListOptions
+	Code string `url:"code,omitempty"`
+}
+
+// Create a coupon
+func (c *CouponServiceOp) Create(coupon Coupon) (*Coupon, error) {
+	return c.resource().Create(coupon)
+}
+
+// Get individual coupon
+func (c *CouponServiceOp) Get(couponID int64, options interface{}) (*Coupon, error) {
+	return c.resource().Get(couponID, options)
+}
+
+func (c *CouponServiceOp) List(options interface{}) ([]Coupon, error) {
+	coupons, _, err := c.ListWithPages(options)
+	return coupons, err
+}
+
+// ListWithPages lists coupons and returns pagination to retrieve next/previous results.
+func (c *CouponServiceOp) ListWithPages(options interface{}) ([]Coupon, *Pagination, error) {
+	if err := validateListOptions(options); err != nil {
+		return nil, nil, err
+	}
+	return c.resource().List(options)
+}
+
+func (c *CouponServiceOp) Update(coupon *Coupon) (*Coupon, error) {
+	return c.resource().Update(coupon.ID, coupon)
+}
+
+// Patch updates the listed properties of a coupon, see Patch
+func (c *CouponServiceOp) Patch(couponID int64, patch Patch) (*Coupon, error) {
+	return c.resource().Patch(couponID, patch)
+}
+
+func (c *CouponServiceOp) Delete(couponID int64, options interface{}) (*Coupon, error) {
+	return c.resource().Delete(couponID, options)
+}
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each coupon
+func (c *CouponServiceOp) BatchAll(data BatchRequest[Coupon], options *BatchOption) (*BatchResult[Coupon], error) {
+	return c.resource().Batch(data, options)
+}
+
+func (c *CouponServiceOp) resource() *Resource[Coupon] {
+	return NewResource[Coupon](c.client, couponsBasePath)
+}
+
+// Coupon represents a WooCommerce Coupon
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#coupon-properties
+type Coupon struct {
+	ID                        int64        `json:"id,omitempty"`
+	Code                      string       `json:"code,omitempty"`
+	Amount                    Amount       `json:"amount,omitempty"`
+	DateCreated               *Time        `json:"date_created,omitempty"`
+	DateCreatedGmt            *Time        `json:"date_created_gmt,omitempty"`
+	DateModified              *Time        `json:"date_modified,omitempty"`
+	DateModifiedGmt           *Time        `json:"date_modified_gmt,omitempty"`
+	DiscountType              string       `json:"discount_type,omitempty"`
+	Description               string       `json:"description,omitempty"`
+	DateExpires               *Time        `json:"date_expires,omitempty"`
+	DateExpiresGmt            *Time        `json:"date_expires_gmt,omitempty"`
+	UsageCount                int          `json:"usage_count,omitempty"`
+	IndividualUse             bool         `json:"individual_use,omitempty"`
+	ProductIds                []int64      `json:"product_ids,omitempty"`
+	ExcludedProductIds        []int64      `json:"excluded_product_ids,omitempty"`
+	UsageLimit                *int         `json:"usage_limit,omitempty"`
+	UsageLimitPerUser         *int         `json:"usage_limit_per_user,omitempty"`
+	LimitUsageToXItems        *int         `json:"limit_usage_to_x_items,omitempty"`
+	FreeShipping              bool         `json:"free_shipping,omitempty"`
+	ProductCategories         []int64      `json:"product_categories,omitempty"`
+	ExcludedProductCategories []int64      `json:"excluded_product_categories,omitempty"`
+	ExcludeSaleItems          bool         `json:"exclude_sale_items,omitempty"`
+	MinimumAmount             Amount       `json:"minimum_amount,omitempty"`
+	MaximumAmount             Amount       `json:"maximum_amount,omitempty"`
+	EmailRestrictions         []string     `json:"email_restrictions,omitempty"`
+	UsedBy                    []string     `json:"used_by,omitempty"`
+	MetaData                  MetaDataList `json:"meta_data,omitempty"`
+	Links                     Links        `json:"_links,omitempty"`
+	Extra                     Extra        `json:"-"`
+}
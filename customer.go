@@ -2,7 +2,7 @@ package woocommerce
 
 import (
 	"fmt"
-	"net/http"
+	"strconv"
 )
 
 const (
@@ -15,9 +15,14 @@ type CustomerService interface {
 	Create(customer Customer) (*Customer, error)
 	Get(customerID int64, options interface{}) (*Customer, error)
 	List(options interface{}) ([]Customer, error)
+	ListWithPages(options interface{}) ([]Customer, *Pagination, error)
 	Update(customer *Customer) (*Customer, error)
+	Patch(customerID int64, patch Patch) (*Customer, error)
+	UpdateChanged(original, changed *Customer, options *UpdateChangedOption) (*Customer, error)
 	Delete(customerID int64, options interface{}) (*Customer, error)
 	Batch(option CustomerBatchOption) (*CustomerBatchResource, error)
+	BatchAll(data CustomerBatchOption, options *BatchOption) (*BatchResult[Customer], error)
+	Downloads(customerID int64) ([]CustomerDownload, error)
 }
 
 // CustomerServiceOp handles communication with the customer related methods of the WooCommerce API
@@ -52,77 +57,134 @@ type CustomerBatchResource struct {
 // Customer represents a WooCommerce Customer
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#customer-properties
 type Customer struct {
-	ID              int64      `json:"id,omitempty"`
-	Email           string     `json:"email,omitempty"`
-	FirstName       string     `json:"first_name,omitempty"`
-	LastName        string     `json:"last_name,omitempty"`
-	Role            string     `json:"role,omitempty"`
-	Username        string     `json:"username,omitempty"`
-	Billing         *Billing   `json:"billing,omitempty"`
-	Shipping        *Shipping  `json:"shipping,omitempty"`
-	DateCreated     string     `json:"date_created,omitempty"`
-	DateCreatedGmt  string     `json:"date_created_gmt,omitempty"`
-	DateModified    string     `json:"date_modified,omitempty"`
-	DateModifiedGmt string     `json:"date_modified_gmt,omitempty"`
-	OrdersCount     int        `json:"orders_count,omitempty"`
-	TotalSpent      string     `json:"total_spent,omitempty"`
-	AvatarURL       string     `json:"avatar_url,omitempty"`
-	MetaData        []MetaData `json:"meta_data,omitempty"`
-	Links           Links      `json:"_links,omitempty"`
+	ID              int64        `json:"id,omitempty"`
+	Email           string       `json:"email,omitempty"`
+	FirstName       string       `json:"first_name,omitempty"`
+	LastName        string       `json:"last_name,omitempty"`
+	Role            string       `json:"role,omitempty"`
+	Username        string       `json:"username,omitempty"`
+	Billing         *Billing     `json:"billing,omitempty"`
+	Shipping        *Shipping    `json:"shipping,omitempty"`
+	DateCreated     *Time        `json:"date_created,omitempty"`
+	DateCreatedGmt  *Time        `json:"date_created_gmt,omitempty"`
+	DateModified    *Time        `json:"date_modified,omitempty"`
+	DateModifiedGmt *Time        `json:"date_modified_gmt,omitempty"`
+	OrdersCount     int          `json:"orders_count,omitempty"`
+	TotalSpent      Amount       `json:"total_spent,omitempty"`
+	AvatarURL       string       `json:"avatar_url,omitempty"`
+	MetaData        MetaDataList `json:"meta_data,omitempty"`
+	Links           Links        `json:"_links,omitempty"`
+	Extra           Extra        `json:"-"`
+}
+
+// CustomerDownload represents a download permission granted to a customer
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#customer-downloads-properties
+// The REST API exposes these permissions read-only, access_expires and downloads_remaining
+// can't be extended through it. OrderService.ResetDownloadPermissions has them granted again
+// when the order next moves to processing or completed.
+type CustomerDownload struct {
+	DownloadID         string    `json:"download_id,omitempty"`
+	DownloadURL        string    `json:"download_url,omitempty"`
+	ProductID          int64     `json:"product_id,omitempty"`
+	ProductName        string    `json:"product_name,omitempty"`
+	DownloadName       string    `json:"download_name,omitempty"`
+	OrderID            int64     `json:"order_id,omitempty"`
+	OrderKey           string    `json:"order_key,omitempty"`
+	DownloadsRemaining string    `json:"downloads_remaining,omitempty"`
+	AccessExpires      *Time     `json:"access_expires,omitempty"`
+	AccessExpiresGmt   *Time     `json:"access_expires_gmt,omitempty"`
+	File               *Download `json:"file,omitempty"`
+	Links              Links     `json:"_links,omitempty"`
+	Extra              Extra     `json:"-"`
+}
+
+// UnlimitedDownloads reports whether the permission has no download limit
+func (d CustomerDownload) UnlimitedDownloads() bool {
+	return d.DownloadsRemaining == "" || d.DownloadsRemaining == "unlimited"
+}
+
+// Remaining returns the number of downloads left, ok is false when downloads are unlimited
+func (d CustomerDownload) Remaining() (remaining int, ok bool) {
+	if d.UnlimitedDownloads() {
+		return 0, false
+	}
+	remaining, err := strconv.Atoi(d.DownloadsRemaining)
+	if err != nil {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// NeverExpires reports whether access to the download never expires
+func (d CustomerDownload) NeverExpires() bool {
+	return d.AccessExpires == nil || d.AccessExpires.IsZero()
 }
 
 func (c *CustomerServiceOp) List(options interface{}) ([]Customer, error) {
-	customers, err := c.ListWithPagination(options)
+	customers, _, err := c.ListWithPages(options)
 	return customers, err
 }
 
-// ListWithPagination lists customers and returns pagination to retrieve next/previous results.
+// ListWithPagination lists customers
+//
+// Deprecated: ListWithPagination doesn't return the pagination, use ListWithPages.
 func (c *CustomerServiceOp) ListWithPagination(options interface{}) ([]Customer, error) {
-	path := fmt.Sprintf("%s", customersBasePath)
-	resource := make([]Customer, 0)
-	headers := http.Header{}
-	headers, err := c.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, err
-	}
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-	fmt.Println(linkHeader)
-	// pagination, err := extractPagination(linkHeader)
-	// if err != nil {
-	// 	return nil, nil, err
-	// }
+	return c.List(options)
+}
 
-	return resource, err
+// ListWithPages lists customers and returns pagination to retrieve next/previous results.
+func (c *CustomerServiceOp) ListWithPages(options interface{}) ([]Customer, *Pagination, error) {
+	return c.resource().List(options)
 }
 
 func (c *CustomerServiceOp) Create(customer Customer) (*Customer, error) {
-	path := fmt.Sprintf("%s", customersBasePath)
-	resource := new(Customer)
-	err := c.client.Post(path, customer, &resource)
-	return resource, err
+	return c.resource().Create(customer)
 }
 
 // Get individual customer
 func (c *CustomerServiceOp) Get(customerID int64, options interface{}) (*Customer, error) {
-	path := fmt.Sprintf("%s/%d", customersBasePath, customerID)
-	resource := new(Customer)
-	err := c.client.Get(path, resource, options)
-	return resource, err
+	return c.resource().Get(customerID, options)
 }
 
 func (c *CustomerServiceOp) Update(customer *Customer) (*Customer, error) {
-	path := fmt.Sprintf("%s/%d", customersBasePath, customer.ID)
-	resource := new(Customer)
-	err := c.client.Put(path, customer, &resource)
-	return resource, err
+	return c.resource().Update(customer.ID, customer)
+}
+
+// Patch updates the listed properties of a customer, see Patch
+func (c *CustomerServiceOp) Patch(customerID int64, patch Patch) (*Customer, error) {
+	return c.resource().Patch(customerID, patch)
+}
+
+// UpdateChanged updates a customer with the properties of changed that differ from original,
+// the customer as fetched, see Diff. Make changed with original.Clone(). Nothing is sent when they
+// are equal and the error is ErrNoChanges.
+func (c *CustomerServiceOp) UpdateChanged(original, changed *Customer, options *UpdateChangedOption) (*Customer, error) {
+	patch, err := diffForUpdate(original.ID, changed.ID, original, changed)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return changed, ErrNoChanges
+	}
+	if options != nil && options.CheckModified {
+		current, err := c.Get(original.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkModified(original.DateModifiedGmt, current.DateModifiedGmt); err != nil {
+			return current, err
+		}
+	}
+	return c.Patch(original.ID, patch)
+}
+
+// Clone returns a deep copy of the customer, to change and pass to UpdateChanged with the original
+func (c *Customer) Clone() *Customer {
+	return cloneOf(c)
 }
 
 func (c *CustomerServiceOp) Delete(customerID int64, options interface{}) (*Customer, error) {
-	path := fmt.Sprintf("%s/%d", customersBasePath, customerID)
-	resource := new(Customer)
-	err := c.client.Delete(path, options, &resource)
-	return resource, err
+	return c.resource().Delete(customerID, options)
 }
 
 func (c *CustomerServiceOp) Batch(data CustomerBatchOption) (*CustomerBatchResource, error) {
@@ -131,3 +193,21 @@ func (c *CustomerServiceOp) Batch(data CustomerBatchOption) (*CustomerBatchResou
 	err := c.client.Post(path, data, &resource)
 	return resource, err
 }
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each customer
+func (c *CustomerServiceOp) BatchAll(data CustomerBatchOption, options *BatchOption) (*BatchResult[Customer], error) {
+	return c.resource().Batch(BatchRequest[Customer](data), options)
+}
+
+func (c *CustomerServiceOp) resource() *Resource[Customer] {
+	return NewResource[Customer](c.client, customersBasePath)
+}
+
+// Downloads lists the download permissions of a customer
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#retrieve-customer-downloads
+func (c *CustomerServiceOp) Downloads(customerID int64) ([]CustomerDownload, error) {
+	path := fmt.Sprintf("%s/%d/downloads", customersBasePath, customerID)
+	resource := make([]CustomerDownload, 0)
+	err := c.client.Get(path, &resource, nil)
+	return resource, err
+}
@@ -0,0 +1,67 @@
+package woocommerce
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCustomerServiceOp_Downloads(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wp-json/wc/v3/customers/26/downloads" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[
+			{
+				"download_id": "91447fd1849316bbc89dfb7e986a6006",
+				"download_url": "https://example.com/?download_file=96&order=wc_order_58d17c18352&email=john.dooe%40example.com&key=91447fd1849316bbc89dfb7e986a6006",
+				"product_id": 96,
+				"product_name": "Woo Album #2",
+				"download_name": "Woo Album #2 &ndash; Song 2",
+				"order_id": 723,
+				"order_key": "wc_order_58d17c18352",
+				"downloads_remaining": "3",
+				"access_expires": "never",
+				"access_expires_gmt": "never",
+				"file": {"name": "Song 2", "file": "https://example.com/wp-content/uploads/2017/08/song2.mp3"},
+				"_links": {"collection": [{"href": "https://example.com/wp-json/wc/v3/customers/26/downloads"}]}
+			},
+			{
+				"download_id": "8a3c5d2f1e9b4a7c6d0e2f1a3b5c7d9e",
+				"download_url": "https://example.com/?download_file=97&order=wc_order_58d17c18352&email=john.dooe%40example.com&key=8a3c5d2f1e9b4a7c6d0e2f1a3b5c7d9e",
+				"product_id": 97,
+				"product_name": "Woo Single #1",
+				"download_name": "Woo Single #1 &ndash; Single",
+				"order_id": 723,
+				"order_key": "wc_order_58d17c18352",
+				"downloads_remaining": null,
+				"access_expires": "2025-03-01T00:00:00",
+				"access_expires_gmt": "2025-03-01T00:00:00",
+				"file": {"name": "Single", "file": "https://example.com/wp-content/uploads/2017/08/single.mp3"}
+			}
+		]`))
+	}))
+
+	downloads, err := c.Customer.Downloads(26)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(downloads) != 2 {
+		t.Fatalf("Downloads() = %+v", downloads)
+	}
+
+	album := downloads[0]
+	if album.OrderID != 723 || album.OrderKey != "wc_order_58d17c18352" || album.File.Name != "Song 2" {
+		t.Errorf("download = %+v", album)
+	}
+	if remaining, ok := album.Remaining(); !ok || remaining != 3 || album.UnlimitedDownloads() {
+		t.Errorf("Remaining() = %d, %v", remaining, ok)
+	}
+	if !album.NeverExpires() {
+		t.Errorf("access expires %v, want never", album.AccessExpires)
+	}
+
+	single := downloads[1]
+	if !single.UnlimitedDownloads() || single.NeverExpires() || single.AccessExpiresGmt.Year() != 2025 {
+		t.Errorf("download = %+v", single)
+	}
+}
@@ -0,0 +1,328 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// itemRemovals maps the lists WooCommerce updates item by item, by their id, to the property
+// that removes an item when sent as null. Other lists are replaced as a whole.
+var itemRemovals = map[string]string{
+	"line_items":     "product_id",
+	"shipping_lines": "method_id",
+	"fee_lines":      "name",
+	"coupon_lines":   "code",
+	"meta_data":      "value",
+}
+
+// itemKeys maps lists to the property sent with every changed or removed item besides its id.
+// WooCommerce updates and deletes meta data by key and id, an entry without key is lost.
+var itemKeys = map[string]string{
+	"meta_data": "key",
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	extraType         = reflect.TypeOf(Extra(nil))
+)
+
+// ErrConcurrentModification is returned by the UpdateChanged methods when the resource was
+// modified since the original was fetched
+var ErrConcurrentModification = errors.New("woocommerce: resource modified concurrently")
+
+// ErrNoChanges is returned by the UpdateChanged methods, with changed, when changed doesn't
+// differ from original and nothing was sent
+var ErrNoChanges = errors.New("woocommerce: no changes to update")
+
+// UpdateChangedOption configures the UpdateChanged methods. CheckModified fetches the resource
+// before updating it and fails with ErrConcurrentModification if its date_modified_gmt differs
+// from the original's. The check narrows the window for lost updates but isn't atomic.
+type UpdateChangedOption struct {
+	CheckModified bool
+}
+
+// Diff returns a Patch with the JSON properties of changed that differ from original, two
+// values of the same model type. Nested objects such as billing only carry their changed
+// properties. Line items, shipping, fee and coupon lines and meta data are diffed by id:
+// changed items are sent with their id and changed properties, items without id are added
+// and items missing from changed are removed. Other lists are sent whole when they differ.
+// Changed and added properties of Extra are sent, removed ones are left as is.
+//
+// changed must not share pointers, slices or maps with original, or edits through them change
+// both and are lost: make it with the Clone method of the model.
+func Diff(original, changed interface{}) (Patch, error) {
+	o, c := reflect.ValueOf(original), reflect.ValueOf(changed)
+	if o.Type() != c.Type() {
+		return nil, fmt.Errorf("woocommerce: diff of %T and %T", original, changed)
+	}
+	for o.Kind() == reflect.Ptr {
+		if o.IsNil() || c.IsNil() {
+			return nil, fmt.Errorf("woocommerce: diff of nil %T", original)
+		}
+		o, c = o.Elem(), c.Elem()
+	}
+	if o.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("woocommerce: diff of non struct %T", original)
+	}
+	return diffStruct(o, c), nil
+}
+
+// diffStruct returns the changed properties of the struct values o and c
+func diffStruct(o, c reflect.Value) Patch {
+	patch := Patch{}
+	t := o.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonName(f)
+		if f.IsExported() && f.Type == extraType {
+			diffExtra(patch, t, o.Field(i).Interface().(Extra), c.Field(i).Interface().(Extra))
+			continue
+		}
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		of, cf := o.Field(i), c.Field(i)
+		if reflect.DeepEqual(of.Interface(), cf.Interface()) {
+			continue
+		}
+
+		if removal, ok := itemRemovals[name]; ok && of.Kind() == reflect.Slice {
+			if items, ok := diffItems(of, cf, removal, itemKeys[name]); ok {
+				patch[name] = items
+				continue
+			}
+		}
+		if isNestedStruct(cf) {
+			if of.Kind() == reflect.Ptr && !of.IsNil() && !cf.IsNil() {
+				patch[name] = diffStruct(of.Elem(), cf.Elem())
+				continue
+			}
+			if of.Kind() == reflect.Struct {
+				patch[name] = diffStruct(of, cf)
+				continue
+			}
+			if cf.Kind() == reflect.Ptr && cf.IsNil() {
+				// a removed nested object can't be cleared, leave it as is
+				continue
+			}
+		}
+		patch[name] = cf.Interface()
+	}
+	return patch
+}
+
+// diffExtra adds the properties of Extra c that differ from o to patch, but not those of the
+// model type t. Removed properties can't be cleared and are left as is.
+func diffExtra(patch Patch, t reflect.Type, o, c Extra) {
+	known := jsonProperties(t)
+	for name, value := range c {
+		if known[strings.ToLower(name)] || bytes.Equal(o[name], value) {
+			continue
+		}
+		patch[name] = value
+	}
+}
+
+// diffItems diffs two lists of structs by their id, ok is false when the items have no id. The
+// property key, if any, is sent with every changed and removed item.
+func diffItems(o, c reflect.Value, removal, key string) ([]Patch, bool) {
+	originals := make(map[int64]reflect.Value, o.Len())
+	for i := 0; i < o.Len(); i++ {
+		id, ok := itemID(o.Index(i))
+		if !ok {
+			return nil, false
+		}
+		if id != 0 {
+			originals[id] = o.Index(i)
+		}
+	}
+
+	items := make([]Patch, 0)
+	kept := make(map[int64]bool, c.Len())
+	for i := 0; i < c.Len(); i++ {
+		item := c.Index(i)
+		id, ok := itemID(item)
+		if !ok {
+			return nil, false
+		}
+		original, found := originals[id]
+		if id == 0 || !found {
+			// new item, sent whole so omitempty applies like on create
+			full, err := toPatch(item.Interface())
+			if err != nil {
+				return nil, false
+			}
+			items = append(items, full)
+			continue
+		}
+		kept[id] = true
+		if changes := diffStruct(indirect(original), indirect(item)); len(changes) > 0 {
+			changes["id"] = id
+			addItemKey(changes, item, key)
+			items = append(items, changes)
+		}
+	}
+	for i := 0; i < o.Len(); i++ {
+		id, _ := itemID(o.Index(i))
+		if id != 0 && !kept[id] {
+			removed := Patch{"id": id, removal: nil}
+			addItemKey(removed, o.Index(i), key)
+			items = append(items, removed)
+		}
+	}
+	return items, true
+}
+
+// addItemKey sets the property key of item on patch
+func addItemKey(patch Patch, item reflect.Value, key string) {
+	if key == "" {
+		return
+	}
+	if v, ok := jsonField(indirect(item), key); ok {
+		patch[key] = v.Interface()
+	}
+}
+
+// itemID returns the id property of a list item
+func itemID(item reflect.Value) (int64, bool) {
+	item = indirect(item)
+	if item.Kind() != reflect.Struct {
+		return 0, false
+	}
+	id, ok := jsonField(item, "id")
+	if !ok {
+		return 0, false
+	}
+	switch id.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return id.Int(), true
+	}
+	return 0, false
+}
+
+// hasExtra reports whether t is a model struct keeping its unknown properties in Extra, its
+// JSON methods only add them
+func hasExtra(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	f, ok := t.FieldByName("Extra")
+	return ok && f.Type == extraType
+}
+
+// isNestedStruct reports whether v is a struct, or pointer to one, diffed property by property
+// rather than as a value like Time
+func isNestedStruct(v reflect.Value) bool {
+	t := v.Type()
+	if hasExtra(indirectType(t)) {
+		return true
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+			return false
+		}
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// toPatch converts a model value to a Patch of its JSON encoding
+func toPatch(v interface{}) (Patch, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	patch := Patch{}
+	err = json.Unmarshal(data, &patch)
+	return patch, err
+}
+
+// checkModified fails with ErrConcurrentModification when the date_modified_gmt of the
+// resource changed since original was fetched
+func checkModified(original, current *Time) error {
+	if original == nil || current == nil || original.Equal(current.Time) {
+		return nil
+	}
+	return fmt.Errorf("%w: modified at %s, original from %s", ErrConcurrentModification, current, original)
+}
+
+// cloneOf returns a deep copy of the value v points to, see clone
+func cloneOf[T any](v *T) *T {
+	return clone(reflect.ValueOf(v)).Interface().(*T)
+}
+
+// clone returns a deep copy of v: pointers, slices, maps and interfaces of its exported
+// fields are copied, unexported fields are copied as is
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(clone(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(clone(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), clone(iter.Value()))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(clone(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(clone(v.Field(i)))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// diffForUpdate diffs two versions of a resource with the given IDs
+func diffForUpdate(originalID, changedID int64, original, changed interface{}) (Patch, error) {
+	if originalID != changedID {
+		return nil, fmt.Errorf("woocommerce: changed %T has ID %d, original %d", changed, changedID, originalID)
+	}
+	patch, err := Diff(original, changed)
+	if err != nil {
+		return nil, err
+	}
+	delete(patch, "id")
+	return patch, nil
+}
@@ -0,0 +1,127 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	original := &Order{
+		ID:       727,
+		Status:   "processing",
+		Billing:  &Billing{FirstName: "John", City: "Athens"},
+		MetaData: []MetaData{{ID: 1, Key: "gift", Value: "yes"}, {ID: 2, Key: "ref", Value: "x"}},
+		LineItems: []LineItem{
+			{ID: 10, ProductID: 93, Quantity: 2, Total: "20.00"},
+			{ID: 11, ProductID: 22, Quantity: 1, Total: "5.00"},
+		},
+	}
+	changed := *original
+	changed.Status = "completed"
+	changed.Billing = &Billing{FirstName: "John", City: "Thessaloniki"}
+	changed.MetaData = []MetaData{{ID: 1, Key: "gift", Value: "no"}, {ID: 2, Key: "ref", Value: "x"}}
+	changed.LineItems = []LineItem{
+		{ID: 10, ProductID: 93, Quantity: 3, Total: "20.00"},
+		{ProductID: 55, Quantity: 1},
+	}
+
+	patch, err := Diff(original, &changed)
+	if err != nil {
+		t.Fatalf("diff fail: %v", err)
+	}
+	got, _ := json.Marshal(patch)
+	want := `{"billing":{"city":"Thessaloniki"},` +
+		`"line_items":[{"id":10,"quantity":3},{"product_id":55,"quantity":1},{"id":11,"product_id":null}],` +
+		`"meta_data":[{"id":1,"key":"gift","value":"no"}],"status":"completed"}`
+	if string(got) != want {
+		t.Errorf("patch = %s\nwant    %s", got, want)
+	}
+}
+
+func TestDiff_MetaDataKeys(t *testing.T) {
+	original := &Order{MetaData: MetaDataList{{ID: 1, Key: "gift", Value: "yes"}, {ID: 2, Key: "ref", Value: "x"}, {ID: 3, Key: "old", Value: "y"}}}
+	changed := original.Clone()
+	changed.MetaData.Set("gift", "no")
+	changed.MetaData.Delete("ref")
+	changed.MetaData = changed.MetaData[:2]
+
+	patch, err := Diff(original, changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(patch)
+	want := `{"meta_data":[{"id":1,"key":"gift","value":"no"},{"id":2,"key":"ref","value":null},{"id":3,"key":"old","value":null}]}`
+	if string(got) != want {
+		t.Errorf("patch = %s\nwant    %s", got, want)
+	}
+}
+
+func TestOrderServiceOp_UpdateChanged(t *testing.T) {
+	modified := "2024-03-10T13:30:00"
+	var body string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id": 727, "date_modified_gmt": "` + modified + `"}`))
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			w.Write([]byte(`{"id": 727}`))
+		}
+	}))
+
+	original, err := c.Order.Get(727, nil)
+	if err != nil {
+		t.Fatalf("get fail: %v", err)
+	}
+	changed := *original.Clone()
+	changed.CustomerNote = "leave at the door"
+	if _, err := c.Order.UpdateChanged(original, &changed, &UpdateChangedOption{CheckModified: true}); err != nil {
+		t.Fatalf("update changed fail: %v", err)
+	}
+	if body != `{"customer_note":"leave at the door"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	modified = "2024-03-10T13:45:00"
+	_, err = c.Order.UpdateChanged(original, &changed, &UpdateChangedOption{CheckModified: true})
+	if !errors.Is(err, ErrConcurrentModification) {
+		t.Errorf("expected concurrent modification, got %v", err)
+	}
+}
+
+func TestOrderServiceOp_UpdateChangedClone(t *testing.T) {
+	var body string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"id": 727}`))
+	}))
+
+	original := &Order{
+		ID:       727,
+		Billing:  &Billing{FirstName: "John", Email: "john@example.com"},
+		MetaData: MetaDataList{{ID: 5, Key: "_gift", Value: "no"}},
+	}
+	changed := original.Clone()
+	changed.Billing.Email = "jane@example.com"
+	changed.MetaData[0].Value = "yes"
+	if original.Billing.Email != "john@example.com" || original.MetaData[0].Value != "no" {
+		t.Fatalf("clone shares values with the original %+v", original)
+	}
+	if _, err := c.Order.UpdateChanged(original, changed, nil); err != nil {
+		t.Fatalf("update changed fail: %v", err)
+	}
+	want := `{"billing":{"email":"jane@example.com"},"meta_data":[{"id":5,"key":"_gift","value":"yes"}]}`
+	if body != want {
+		t.Errorf("body = %s\nwant   %s", body, want)
+	}
+
+	body = ""
+	if _, err := c.Order.UpdateChanged(original, original.Clone(), nil); !errors.Is(err, ErrNoChanges) || body != "" {
+		t.Errorf("unchanged update = %v, sent %q", err, body)
+	}
+}
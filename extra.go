@@ -0,0 +1,910 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extra holds the properties of a resource its model doesn't know, added by plugins or newer
+// WooCommerce versions. They are kept when decoding and sent back when encoding, properties
+// of the model take precedence. The models nested in a resource, such as its billing address
+// or line items, have their own Extra.
+//
+//	var points int
+//	ok, err := customer.Extra.Decode("loyalty_points", &points)
+type Extra map[string]json.RawMessage
+
+// Decode decodes the property name into v, a pointer, ok is false when it's missing
+func (e Extra) Decode(name string, v interface{}) (bool, error) {
+	data, ok := e[name]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// Set sets the property name to the JSON encoding of value
+func (e *Extra) Set(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if *e == nil {
+		*e = Extra{}
+	}
+	(*e)[name] = data
+	return nil
+}
+
+// knownProperties caches the JSON property names of the model types, lower cased like the
+// matching of encoding/json
+var knownProperties sync.Map
+
+// unmarshalExtra decodes data into v, a pointer to a model type without the UnmarshalJSON
+// method, and sets extra to the properties v doesn't have
+func unmarshalExtra(data []byte, v interface{}, extra *Extra) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*extra = nil
+	if len(data) == 0 || data[0] != '{' {
+		return nil
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	known := jsonProperties(reflect.TypeOf(v).Elem())
+	for name, value := range properties {
+		if !known[strings.ToLower(name)] {
+			if *extra == nil {
+				*extra = Extra{}
+			}
+			(*extra)[name] = value
+		}
+	}
+	return nil
+}
+
+// marshalExtra encodes v, a model value without the MarshalJSON method, with the properties
+// of extra it doesn't have
+func marshalExtra(v interface{}, extra Extra) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	known := jsonProperties(reflect.TypeOf(v))
+	for name, value := range extra {
+		if !known[strings.ToLower(name)] {
+			properties[name] = value
+		}
+	}
+	return json.Marshal(properties)
+}
+
+// jsonProperties returns the lower cased JSON property names of a struct type
+func jsonProperties(t reflect.Type) map[string]bool {
+	if known, ok := knownProperties.Load(t); ok {
+		return known.(map[string]bool)
+	}
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" && indirectType(f.Type).Kind() == reflect.Struct {
+			for name := range jsonProperties(indirectType(f.Type)) {
+				known[name] = true
+			}
+			continue
+		}
+		if name := jsonName(f); name != "" && f.IsExported() {
+			known[strings.ToLower(name)] = true
+		}
+	}
+	knownProperties.Store(t, known)
+	return known
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (o *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+	return unmarshalExtra(data, (*order)(o), &o.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (o Order) MarshalJSON() ([]byte, error) {
+	type order Order
+	return marshalExtra(order(o), o.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *OrderRefund) UnmarshalJSON(data []byte) error {
+	type orderRefund OrderRefund
+	return unmarshalExtra(data, (*orderRefund)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r OrderRefund) MarshalJSON() ([]byte, error) {
+	type orderRefund OrderRefund
+	return marshalExtra(orderRefund(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (n *OrderNote) UnmarshalJSON(data []byte) error {
+	type orderNote OrderNote
+	return unmarshalExtra(data, (*orderNote)(n), &n.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (n OrderNote) MarshalJSON() ([]byte, error) {
+	type orderNote OrderNote
+	return marshalExtra(orderNote(n), n.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type product Product
+	return unmarshalExtra(data, (*product)(p), &p.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (p Product) MarshalJSON() ([]byte, error) {
+	type product Product
+	return marshalExtra(product(p), p.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (v *ProductVariation) UnmarshalJSON(data []byte) error {
+	type productVariation ProductVariation
+	return unmarshalExtra(data, (*productVariation)(v), &v.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (v ProductVariation) MarshalJSON() ([]byte, error) {
+	type productVariation ProductVariation
+	return marshalExtra(productVariation(v), v.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	return unmarshalExtra(data, (*customer)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c Customer) MarshalJSON() ([]byte, error) {
+	type customer Customer
+	return marshalExtra(customer(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (d *CustomerDownload) UnmarshalJSON(data []byte) error {
+	type customerDownload CustomerDownload
+	return unmarshalExtra(data, (*customerDownload)(d), &d.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (d CustomerDownload) MarshalJSON() ([]byte, error) {
+	type customerDownload CustomerDownload
+	return marshalExtra(customerDownload(d), d.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *Coupon) UnmarshalJSON(data []byte) error {
+	type coupon Coupon
+	return unmarshalExtra(data, (*coupon)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c Coupon) MarshalJSON() ([]byte, error) {
+	type coupon Coupon
+	return marshalExtra(coupon(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	type webhook Webhook
+	return unmarshalExtra(data, (*webhook)(w), &w.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type webhook Webhook
+	return marshalExtra(webhook(w), w.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (g *PaymentGateway) UnmarshalJSON(data []byte) error {
+	type paymentGateway PaymentGateway
+	return unmarshalExtra(data, (*paymentGateway)(g), &g.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (g PaymentGateway) MarshalJSON() ([]byte, error) {
+	type paymentGateway PaymentGateway
+	return marshalExtra(paymentGateway(g), g.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (m *Media) UnmarshalJSON(data []byte) error {
+	type media Media
+	return unmarshalExtra(data, (*media)(m), &m.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (m Media) MarshalJSON() ([]byte, error) {
+	type media Media
+	return marshalExtra(media(m), m.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (b *Billing) UnmarshalJSON(data []byte) error {
+	type billing Billing
+	return unmarshalExtra(data, (*billing)(b), &b.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (b Billing) MarshalJSON() ([]byte, error) {
+	type billing Billing
+	return marshalExtra(billing(b), b.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *Shipping) UnmarshalJSON(data []byte) error {
+	type shipping Shipping
+	return unmarshalExtra(data, (*shipping)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s Shipping) MarshalJSON() ([]byte, error) {
+	type shipping Shipping
+	return marshalExtra(shipping(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (l *LineItem) UnmarshalJSON(data []byte) error {
+	type lineItem LineItem
+	return unmarshalExtra(data, (*lineItem)(l), &l.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (l LineItem) MarshalJSON() ([]byte, error) {
+	type lineItem LineItem
+	return marshalExtra(lineItem(l), l.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (i *ItemTax) UnmarshalJSON(data []byte) error {
+	type itemTax ItemTax
+	return unmarshalExtra(data, (*itemTax)(i), &i.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (i ItemTax) MarshalJSON() ([]byte, error) {
+	type itemTax ItemTax
+	return marshalExtra(itemTax(i), i.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (t *TaxLine) UnmarshalJSON(data []byte) error {
+	type taxLine TaxLine
+	return unmarshalExtra(data, (*taxLine)(t), &t.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (t TaxLine) MarshalJSON() ([]byte, error) {
+	type taxLine TaxLine
+	return marshalExtra(taxLine(t), t.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (f *FeeLine) UnmarshalJSON(data []byte) error {
+	type feeLine FeeLine
+	return unmarshalExtra(data, (*feeLine)(f), &f.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (f FeeLine) MarshalJSON() ([]byte, error) {
+	type feeLine FeeLine
+	return marshalExtra(feeLine(f), f.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *Refund) UnmarshalJSON(data []byte) error {
+	type refund Refund
+	return unmarshalExtra(data, (*refund)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r Refund) MarshalJSON() ([]byte, error) {
+	type refund Refund
+	return marshalExtra(refund(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *ShippingLines) UnmarshalJSON(data []byte) error {
+	type shippingLines ShippingLines
+	return unmarshalExtra(data, (*shippingLines)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s ShippingLines) MarshalJSON() ([]byte, error) {
+	type shippingLines ShippingLines
+	return marshalExtra(shippingLines(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CouponLine) UnmarshalJSON(data []byte) error {
+	type couponLine CouponLine
+	return unmarshalExtra(data, (*couponLine)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CouponLine) MarshalJSON() ([]byte, error) {
+	type couponLine CouponLine
+	return marshalExtra(couponLine(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (d *Dimensions) UnmarshalJSON(data []byte) error {
+	type dimensions Dimensions
+	return unmarshalExtra(data, (*dimensions)(d), &d.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (d Dimensions) MarshalJSON() ([]byte, error) {
+	type dimensions Dimensions
+	return marshalExtra(dimensions(d), d.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (d *Download) UnmarshalJSON(data []byte) error {
+	type download Download
+	return unmarshalExtra(data, (*download)(d), &d.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (d Download) MarshalJSON() ([]byte, error) {
+	type download Download
+	return marshalExtra(download(d), d.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *Category) UnmarshalJSON(data []byte) error {
+	type category Category
+	return unmarshalExtra(data, (*category)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c Category) MarshalJSON() ([]byte, error) {
+	type category Category
+	return marshalExtra(category(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type tag Tag
+	return unmarshalExtra(data, (*tag)(t), &t.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type tag Tag
+	return marshalExtra(tag(t), t.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (i *Image) UnmarshalJSON(data []byte) error {
+	type image Image
+	return unmarshalExtra(data, (*image)(i), &i.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (i Image) MarshalJSON() ([]byte, error) {
+	type image Image
+	return marshalExtra(image(i), i.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *Attribute) UnmarshalJSON(data []byte) error {
+	type attribute Attribute
+	return unmarshalExtra(data, (*attribute)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a Attribute) MarshalJSON() ([]byte, error) {
+	type attribute Attribute
+	return marshalExtra(attribute(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (d *DefaultAttr) UnmarshalJSON(data []byte) error {
+	type defaultAttr DefaultAttr
+	return unmarshalExtra(data, (*defaultAttr)(d), &d.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (d DefaultAttr) MarshalJSON() ([]byte, error) {
+	type defaultAttr DefaultAttr
+	return marshalExtra(defaultAttr(d), d.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (v *VariationAttribute) UnmarshalJSON(data []byte) error {
+	type variationAttribute VariationAttribute
+	return unmarshalExtra(data, (*variationAttribute)(v), &v.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (v VariationAttribute) MarshalJSON() ([]byte, error) {
+	type variationAttribute VariationAttribute
+	return marshalExtra(variationAttribute(v), v.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *Report) UnmarshalJSON(data []byte) error {
+	type report Report
+	return unmarshalExtra(data, (*report)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r Report) MarshalJSON() ([]byte, error) {
+	type report Report
+	return marshalExtra(report(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (t *TotalOrdersReport) UnmarshalJSON(data []byte) error {
+	type totalOrdersReport TotalOrdersReport
+	return unmarshalExtra(data, (*totalOrdersReport)(t), &t.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (t TotalOrdersReport) MarshalJSON() ([]byte, error) {
+	type totalOrdersReport TotalOrdersReport
+	return marshalExtra(totalOrdersReport(t), t.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (t *TotalCustomersReport) UnmarshalJSON(data []byte) error {
+	type totalCustomersReport TotalCustomersReport
+	return unmarshalExtra(data, (*totalCustomersReport)(t), &t.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (t TotalCustomersReport) MarshalJSON() ([]byte, error) {
+	type totalCustomersReport TotalCustomersReport
+	return marshalExtra(totalCustomersReport(t), t.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (t *TotalProductsReport) UnmarshalJSON(data []byte) error {
+	type totalProductsReport TotalProductsReport
+	return unmarshalExtra(data, (*totalProductsReport)(t), &t.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (t TotalProductsReport) MarshalJSON() ([]byte, error) {
+	type totalProductsReport TotalProductsReport
+	return marshalExtra(totalProductsReport(t), t.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *RevenueStats) UnmarshalJSON(data []byte) error {
+	type revenueStats RevenueStats
+	return unmarshalExtra(data, (*revenueStats)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r RevenueStats) MarshalJSON() ([]byte, error) {
+	type revenueStats RevenueStats
+	return marshalExtra(revenueStats(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *RevenueTotals) UnmarshalJSON(data []byte) error {
+	type revenueTotals RevenueTotals
+	return unmarshalExtra(data, (*revenueTotals)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r RevenueTotals) MarshalJSON() ([]byte, error) {
+	type revenueTotals RevenueTotals
+	return marshalExtra(revenueTotals(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *RevenueInterval) UnmarshalJSON(data []byte) error {
+	type revenueInterval RevenueInterval
+	return unmarshalExtra(data, (*revenueInterval)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r RevenueInterval) MarshalJSON() ([]byte, error) {
+	type revenueInterval RevenueInterval
+	return marshalExtra(revenueInterval(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (r *RevenueSegment) UnmarshalJSON(data []byte) error {
+	type revenueSegment RevenueSegment
+	return unmarshalExtra(data, (*revenueSegment)(r), &r.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (r RevenueSegment) MarshalJSON() ([]byte, error) {
+	type revenueSegment RevenueSegment
+	return marshalExtra(revenueSegment(r), r.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (o *OrdersStats) UnmarshalJSON(data []byte) error {
+	type ordersStats OrdersStats
+	return unmarshalExtra(data, (*ordersStats)(o), &o.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (o OrdersStats) MarshalJSON() ([]byte, error) {
+	type ordersStats OrdersStats
+	return marshalExtra(ordersStats(o), o.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (o *OrdersTotals) UnmarshalJSON(data []byte) error {
+	type ordersTotals OrdersTotals
+	return unmarshalExtra(data, (*ordersTotals)(o), &o.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (o OrdersTotals) MarshalJSON() ([]byte, error) {
+	type ordersTotals OrdersTotals
+	return marshalExtra(ordersTotals(o), o.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (o *OrdersInterval) UnmarshalJSON(data []byte) error {
+	type ordersInterval OrdersInterval
+	return unmarshalExtra(data, (*ordersInterval)(o), &o.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (o OrdersInterval) MarshalJSON() ([]byte, error) {
+	type ordersInterval OrdersInterval
+	return marshalExtra(ordersInterval(o), o.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (o *OrdersSegment) UnmarshalJSON(data []byte) error {
+	type ordersSegment OrdersSegment
+	return unmarshalExtra(data, (*ordersSegment)(o), &o.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (o OrdersSegment) MarshalJSON() ([]byte, error) {
+	type ordersSegment OrdersSegment
+	return marshalExtra(ordersSegment(o), o.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsProduct) UnmarshalJSON(data []byte) error {
+	type analyticsProduct AnalyticsProduct
+	return unmarshalExtra(data, (*analyticsProduct)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsProduct) MarshalJSON() ([]byte, error) {
+	type analyticsProduct AnalyticsProduct
+	return marshalExtra(analyticsProduct(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsVariation) UnmarshalJSON(data []byte) error {
+	type analyticsVariation AnalyticsVariation
+	return unmarshalExtra(data, (*analyticsVariation)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsVariation) MarshalJSON() ([]byte, error) {
+	type analyticsVariation AnalyticsVariation
+	return marshalExtra(analyticsVariation(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsProductDetails) UnmarshalJSON(data []byte) error {
+	type analyticsProductDetails AnalyticsProductDetails
+	return unmarshalExtra(data, (*analyticsProductDetails)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsProductDetails) MarshalJSON() ([]byte, error) {
+	type analyticsProductDetails AnalyticsProductDetails
+	return marshalExtra(analyticsProductDetails(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsCategory) UnmarshalJSON(data []byte) error {
+	type analyticsCategory AnalyticsCategory
+	return unmarshalExtra(data, (*analyticsCategory)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsCategory) MarshalJSON() ([]byte, error) {
+	type analyticsCategory AnalyticsCategory
+	return marshalExtra(analyticsCategory(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsCoupon) UnmarshalJSON(data []byte) error {
+	type analyticsCoupon AnalyticsCoupon
+	return unmarshalExtra(data, (*analyticsCoupon)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsCoupon) MarshalJSON() ([]byte, error) {
+	type analyticsCoupon AnalyticsCoupon
+	return marshalExtra(analyticsCoupon(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsTax) UnmarshalJSON(data []byte) error {
+	type analyticsTax AnalyticsTax
+	return unmarshalExtra(data, (*analyticsTax)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsTax) MarshalJSON() ([]byte, error) {
+	type analyticsTax AnalyticsTax
+	return marshalExtra(analyticsTax(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsDownload) UnmarshalJSON(data []byte) error {
+	type analyticsDownload AnalyticsDownload
+	return unmarshalExtra(data, (*analyticsDownload)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsDownload) MarshalJSON() ([]byte, error) {
+	type analyticsDownload AnalyticsDownload
+	return marshalExtra(analyticsDownload(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsStock) UnmarshalJSON(data []byte) error {
+	type analyticsStock AnalyticsStock
+	return unmarshalExtra(data, (*analyticsStock)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsStock) MarshalJSON() ([]byte, error) {
+	type analyticsStock AnalyticsStock
+	return marshalExtra(analyticsStock(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (a *AnalyticsCustomer) UnmarshalJSON(data []byte) error {
+	type analyticsCustomer AnalyticsCustomer
+	return unmarshalExtra(data, (*analyticsCustomer)(a), &a.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (a AnalyticsCustomer) MarshalJSON() ([]byte, error) {
+	type analyticsCustomer AnalyticsCustomer
+	return marshalExtra(analyticsCustomer(a), a.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *Cart) UnmarshalJSON(data []byte) error {
+	type cart Cart
+	return unmarshalExtra(data, (*cart)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c Cart) MarshalJSON() ([]byte, error) {
+	type cart Cart
+	return marshalExtra(cart(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartItem) UnmarshalJSON(data []byte) error {
+	type cartItem CartItem
+	return unmarshalExtra(data, (*cartItem)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartItem) MarshalJSON() ([]byte, error) {
+	type cartItem CartItem
+	return marshalExtra(cartItem(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartItemTotals) UnmarshalJSON(data []byte) error {
+	type cartItemTotals CartItemTotals
+	return unmarshalExtra(data, (*cartItemTotals)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartItemTotals) MarshalJSON() ([]byte, error) {
+	type cartItemTotals CartItemTotals
+	return marshalExtra(cartItemTotals(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartCoupon) UnmarshalJSON(data []byte) error {
+	type cartCoupon CartCoupon
+	return unmarshalExtra(data, (*cartCoupon)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartCoupon) MarshalJSON() ([]byte, error) {
+	type cartCoupon CartCoupon
+	return marshalExtra(cartCoupon(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartFee) UnmarshalJSON(data []byte) error {
+	type cartFee CartFee
+	return unmarshalExtra(data, (*cartFee)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartFee) MarshalJSON() ([]byte, error) {
+	type cartFee CartFee
+	return marshalExtra(cartFee(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartShippingRate) UnmarshalJSON(data []byte) error {
+	type cartShippingRate CartShippingRate
+	return unmarshalExtra(data, (*cartShippingRate)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartShippingRate) MarshalJSON() ([]byte, error) {
+	type cartShippingRate CartShippingRate
+	return marshalExtra(cartShippingRate(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *ShippingRate) UnmarshalJSON(data []byte) error {
+	type shippingRate ShippingRate
+	return unmarshalExtra(data, (*shippingRate)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s ShippingRate) MarshalJSON() ([]byte, error) {
+	type shippingRate ShippingRate
+	return marshalExtra(shippingRate(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartTotals) UnmarshalJSON(data []byte) error {
+	type cartTotals CartTotals
+	return unmarshalExtra(data, (*cartTotals)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartTotals) MarshalJSON() ([]byte, error) {
+	type cartTotals CartTotals
+	return marshalExtra(cartTotals(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CartError) UnmarshalJSON(data []byte) error {
+	type cartError CartError
+	return unmarshalExtra(data, (*cartError)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CartError) MarshalJSON() ([]byte, error) {
+	type cartError CartError
+	return marshalExtra(cartError(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (c *CheckoutOrder) UnmarshalJSON(data []byte) error {
+	type checkoutOrder CheckoutOrder
+	return unmarshalExtra(data, (*checkoutOrder)(c), &c.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (c CheckoutOrder) MarshalJSON() ([]byte, error) {
+	type checkoutOrder CheckoutOrder
+	return marshalExtra(checkoutOrder(c), c.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreProduct) UnmarshalJSON(data []byte) error {
+	type storeProduct StoreProduct
+	return unmarshalExtra(data, (*storeProduct)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreProduct) MarshalJSON() ([]byte, error) {
+	type storeProduct StoreProduct
+	return marshalExtra(storeProduct(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StorePrices) UnmarshalJSON(data []byte) error {
+	type storePrices StorePrices
+	return unmarshalExtra(data, (*storePrices)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StorePrices) MarshalJSON() ([]byte, error) {
+	type storePrices StorePrices
+	return marshalExtra(storePrices(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreImage) UnmarshalJSON(data []byte) error {
+	type storeImage StoreImage
+	return unmarshalExtra(data, (*storeImage)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreImage) MarshalJSON() ([]byte, error) {
+	type storeImage StoreImage
+	return marshalExtra(storeImage(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreProductAttribute) UnmarshalJSON(data []byte) error {
+	type storeProductAttribute StoreProductAttribute
+	return unmarshalExtra(data, (*storeProductAttribute)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreProductAttribute) MarshalJSON() ([]byte, error) {
+	type storeProductAttribute StoreProductAttribute
+	return marshalExtra(storeProductAttribute(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreProductVariation) UnmarshalJSON(data []byte) error {
+	type storeProductVariation StoreProductVariation
+	return unmarshalExtra(data, (*storeProductVariation)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreProductVariation) MarshalJSON() ([]byte, error) {
+	type storeProductVariation StoreProductVariation
+	return marshalExtra(storeProductVariation(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreVariationOption) UnmarshalJSON(data []byte) error {
+	type storeVariationOption StoreVariationOption
+	return unmarshalExtra(data, (*storeVariationOption)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreVariationOption) MarshalJSON() ([]byte, error) {
+	type storeVariationOption StoreVariationOption
+	return marshalExtra(storeVariationOption(s), s.Extra)
+}
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (s *StoreAddToCart) UnmarshalJSON(data []byte) error {
+	type storeAddToCart StoreAddToCart
+	return unmarshalExtra(data, (*storeAddToCart)(s), &s.Extra)
+}
+
+// MarshalJSON sends the properties of Extra
+func (s StoreAddToCart) MarshalJSON() ([]byte, error) {
+	type storeAddToCart StoreAddToCart
+	return marshalExtra(storeAddToCart(s), s.Extra)
+}
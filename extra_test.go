@@ -0,0 +1,125 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExtra(t *testing.T) {
+	data := `{"id":26,"email":"john@example.com","loyalty_points":120,"subscription":{"plan":"gold"}}`
+	var customer Customer
+	if err := json.Unmarshal([]byte(data), &customer); err != nil {
+		t.Fatal(err)
+	}
+	if customer.ID != 26 || customer.Email != "john@example.com" || len(customer.Extra) != 2 {
+		t.Fatalf("customer = %+v", customer)
+	}
+	var points int
+	if ok, err := customer.Extra.Decode("loyalty_points", &points); !ok || err != nil || points != 120 {
+		t.Errorf("Decode(loyalty_points) = %d, %v, %v", points, ok, err)
+	}
+	if ok, _ := customer.Extra.Decode("missing", &points); ok {
+		t.Error("Decode(missing) found a property")
+	}
+
+	customer.Extra.Set("loyalty_points", 150)
+	customer.Extra.Set("email", "ignored@example.com")
+	encoded, err := json.Marshal(customer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"loyalty_points":150`, `"subscription":{"plan":"gold"}`, `"email":"john@example.com"`} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("encoded customer %s misses %s", encoded, want)
+		}
+	}
+
+	// decoding again replaces the previous extra properties
+	if err := json.Unmarshal([]byte(`{"id":26}`), &customer); err != nil || customer.Extra != nil {
+		t.Errorf("extra after decoding again = %v, %v", customer.Extra, err)
+	}
+}
+
+func TestExtra_Nested(t *testing.T) {
+	data := `{"id":727,"billing":{"email":"john@example.com","vat_number":"EL123"},` +
+		`"line_items":[{"id":315,"quantity":2,"_reduced_stock":"2","meta_data":[{"id":1,"key":"size","value":"M","legacy":true}]}]}`
+	var order Order
+	if err := json.Unmarshal([]byte(data), &order); err != nil {
+		t.Fatal(err)
+	}
+	var vat string
+	if ok, err := order.Billing.Extra.Decode("vat_number", &vat); !ok || err != nil || vat != "EL123" {
+		t.Errorf("billing vat_number = %q, %v, %v", vat, ok, err)
+	}
+	item := order.LineItems[0]
+	if item.Quantity != 2 || len(item.Extra) != 1 || len(item.MetaData[0].Extra) != 1 {
+		t.Errorf("line item = %+v", item)
+	}
+	if len(order.Extra) != 0 {
+		t.Errorf("order extra = %v", order.Extra)
+	}
+
+	encoded, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"vat_number":"EL123"`, `"_reduced_stock":"2"`, `"legacy":true`} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("encoded order %s misses %s", encoded, want)
+		}
+	}
+
+	// Diff sends changed extra properties of nested models with their other changes
+	changed := order.Clone()
+	changed.Billing.Extra.Set("vat_number", "EL456")
+	changed.Billing.Phone = "555"
+	patch, err := Diff(&order, changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff, _ := json.Marshal(patch); string(diff) != `{"billing":{"phone":"555","vat_number":"EL456"}}` {
+		t.Errorf("Diff() = %s", diff)
+	}
+}
+
+func TestClient_Raw(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wp-json/wc/v3/orders/17":
+			w.Header().Set("X-Plugin", "1")
+			fmt.Fprint(w, `{"id":17,"new_field":true}`)
+		case "/wp-json/wc-admin/onboarding/tasks":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `[]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"rest_no_route","message":"No route was found matching the URL and request method.","data":{"status":404}}`)
+		}
+	}))
+
+	raw, err := c.Raw("GET", "orders/17", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Status != http.StatusOK || raw.Header.Get("X-Plugin") != "1" || string(raw.Body) != `{"id":17,"new_field":true}` {
+		t.Errorf("Raw() = %d %v %s", raw.Status, raw.Header, raw.Body)
+	}
+
+	raw, err = c.Raw("POST", "/wp-json/wc-admin/onboarding/tasks", nil, nil)
+	if err != nil || raw.Status != http.StatusCreated {
+		t.Errorf("Raw() other namespace = %+v, %v", raw, err)
+	}
+
+	raw, err = c.Raw("GET", "missing", nil, nil)
+	var responseErr ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Code != "rest_no_route" {
+		t.Errorf("Raw() of a missing route error = %v", err)
+	}
+	if raw == nil || raw.Status != http.StatusNotFound || !strings.Contains(string(raw.Body), "rest_no_route") {
+		t.Errorf("Raw() of a missing route = %+v", raw)
+	}
+}
@@ -0,0 +1,185 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	mediaBasePath = "media"
+)
+
+// ErrWordPressAuthRequired is returned by MediaService when the client has no WordPress credentials
+var ErrWordPressAuthRequired = errors.New("woocommerce: media uploads require WordPress credentials, see WithWordPressAuth")
+
+// MediaService is an interface for uploading images to the WordPress media library and
+// attaching them to products.
+// https://developer.wordpress.org/rest-api/reference/media/#create-a-media-item
+type MediaService interface {
+	Upload(upload MediaUpload) (*Media, error)
+	UploadProductImage(productID int64, upload MediaUpload) (*Product, error)
+	UploadVariationImage(productID, variationID int64, upload MediaUpload) (*ProductVariation, error)
+}
+
+// MediaServiceOp handles communication with the media related methods of the WordPress API
+type MediaServiceOp struct {
+	client *Client
+}
+
+// MediaUpload is a local file to upload to the media library
+type MediaUpload struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+	Title       string
+	AltText     string
+	Caption     string
+}
+
+// Media represents a WordPress media library attachment
+type Media struct {
+	ID        int64  `json:"id,omitempty"`
+	Date      *Time  `json:"date,omitempty"`
+	DateGmt   *Time  `json:"date_gmt,omitempty"`
+	Slug      string `json:"slug,omitempty"`
+	Link      string `json:"link,omitempty"`
+	AltText   string `json:"alt_text,omitempty"`
+	MediaType string `json:"media_type,omitempty"`
+	MimeType  string `json:"mime_type,omitempty"`
+	SourceURL string `json:"source_url,omitempty"`
+	Title     struct {
+		Raw      string `json:"raw,omitempty"`
+		Rendered string `json:"rendered,omitempty"`
+	} `json:"title"`
+	Extra Extra `json:"-"`
+}
+
+// Upload sends the file to the media library and returns the new attachment. The title, alt
+// text and caption are set by a second request once the file is uploaded.
+func (m *MediaServiceOp) Upload(upload MediaUpload) (*Media, error) {
+	if m.client.wpUser == "" {
+		return nil, ErrWordPressAuthRequired
+	}
+	if upload.FileName == "" || len(upload.Data) == 0 {
+		return nil, errors.New("woocommerce: media upload needs a file name and data")
+	}
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(upload.FileName)))
+	contentType := upload.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(upload.Data)
+	}
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(upload.Data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	resource := new(Media)
+	if err := m.do("POST", mediaBasePath, body, writer.FormDataContentType(), resource); err != nil {
+		return nil, err
+	}
+	if upload.Title == "" && upload.AltText == "" && upload.Caption == "" {
+		return resource, nil
+	}
+
+	fields, err := json.Marshal(mediaFields{Title: upload.Title, AltText: upload.AltText, Caption: upload.Caption})
+	if err != nil {
+		return nil, err
+	}
+	updated := new(Media)
+	path := fmt.Sprintf("%s/%d", mediaBasePath, resource.ID)
+	if err := m.do("POST", path, bytes.NewReader(fields), "application/json", updated); err != nil {
+		return resource, err
+	}
+	return updated, nil
+}
+
+// mediaFields are the properties of an attachment set after its upload
+type mediaFields struct {
+	Title   string `json:"title,omitempty"`
+	AltText string `json:"alt_text,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// do sends a request to the WordPress media endpoint authenticated with the application
+// password only, the WooCommerce API keys are neither added to the URL nor used to sign it
+func (m *MediaServiceOp) do(method, relPath string, body io.Reader, contentType string, resource interface{}) error {
+	rel, err := url.Parse(path.Join(wordpressPathPrefix, relPath))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, m.client.baseURL.ResolveReference(rel).String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	req.SetBasicAuth(m.client.wpUser, m.client.wpPassword)
+
+	m.client.logRequest(req)
+	resp, err := m.client.sendWith(m.client.Client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(resource)
+}
+
+// UploadProductImage uploads the file and appends it to the product's images
+func (m *MediaServiceOp) UploadProductImage(productID int64, upload MediaUpload) (*Product, error) {
+	product, err := m.client.Product.Get(productID, nil)
+	if err != nil {
+		return nil, err
+	}
+	media, err := m.Upload(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]Image, 0, len(product.Images)+1)
+	for _, image := range product.Images {
+		images = append(images, Image{Id: image.Id})
+	}
+	images = append(images, Image{Id: media.ID, Alt: upload.AltText, Name: upload.Title})
+	return m.client.Product.Update(&Product{ID: productID, Images: images})
+}
+
+// UploadVariationImage uploads the file and sets it as the variation's image
+func (m *MediaServiceOp) UploadVariationImage(productID, variationID int64, upload MediaUpload) (*ProductVariation, error) {
+	media, err := m.Upload(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%d", fmt.Sprintf(variationsBasePath, productID), variationID)
+	data := ProductVariation{Image: &Image{Id: media.ID, Alt: upload.AltText, Name: upload.Title}}
+	resource := new(ProductVariation)
+	err = m.client.Put(path, data, &resource)
+	return resource, err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,134 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newMediaTestClient returns a client with WordPress credentials whose media endpoint
+// records the uploads and the fields set after them
+func newMediaTestClient(t *testing.T, uploads *[]string, fields *[]mediaFields, handler http.HandlerFunc) *Client {
+	t.Helper()
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/wp-json/wp/v2/media") {
+			handler(w, r)
+			return
+		}
+		if r.URL.Query().Has("consumer_key") || r.URL.Query().Has("consumer_secret") {
+			t.Errorf("media request sent the API keys: %s", r.URL)
+		}
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "app password" {
+			t.Errorf("media request auth = %q, %q, %v", user, password, ok)
+		}
+		switch r.URL.Path {
+		case "/wp-json/wp/v2/media":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("multipart body: %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			*uploads = append(*uploads, header.Filename+":"+header.Header.Get("Content-Type")+":"+string(data))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":55,"source_url":"https://example.com/shirt.png"}`)
+		case "/wp-json/wp/v2/media/55":
+			var f mediaFields
+			json.NewDecoder(r.Body).Decode(&f)
+			*fields = append(*fields, f)
+			fmt.Fprintf(w, `{"id":55,"alt_text":%q,"title":{"raw":%q}}`, f.AltText, f.Title)
+		default:
+			t.Errorf("unexpected media request %s %s", r.Method, r.URL)
+		}
+	}))
+	WithWordPressAuth("admin", "app password")(c)
+	return c
+}
+
+func TestMediaServiceOp_Upload(t *testing.T) {
+	var uploads []string
+	var fields []mediaFields
+	c := newMediaTestClient(t, &uploads, &fields, nil)
+
+	if _, err := NewClient(App{}, "https://example.com").Media.Upload(MediaUpload{FileName: "a.png", Data: []byte("x")}); err != ErrWordPressAuthRequired {
+		t.Errorf("Upload() without credentials = %v", err)
+	}
+
+	media, err := c.Media.Upload(MediaUpload{FileName: `red "shirt".png`, ContentType: "image/png", Data: []byte("png data")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if media.ID != 55 || len(fields) != 0 {
+		t.Errorf("Upload() = %+v, fields %v", media, fields)
+	}
+	if len(uploads) != 1 || uploads[0] != `red "shirt".png:image/png:png data` {
+		t.Errorf("uploads = %q", uploads)
+	}
+
+	media, err = c.Media.Upload(MediaUpload{FileName: "shirt.png", Data: []byte("png data"), Title: "Shirt", AltText: "A red shirt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 1 || fields[0] != (mediaFields{Title: "Shirt", AltText: "A red shirt"}) {
+		t.Errorf("fields = %+v", fields)
+	}
+	if media.AltText != "A red shirt" || media.Title.Raw != "Shirt" {
+		t.Errorf("Upload() = %+v", media)
+	}
+}
+
+func TestMediaServiceOp_UploadProductImage(t *testing.T) {
+	var uploads []string
+	var fields []mediaFields
+	var body string
+	c := newMediaTestClient(t, &uploads, &fields, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wc/v3/products/10":
+			fmt.Fprint(w, `{"id":10,"images":[{"id":40,"src":"https://example.com/front.png"}]}`)
+		case "PUT /wp-json/wc/v3/products/10":
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			fmt.Fprint(w, `{"id":10,"images":[{"id":40},{"id":55}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	product, err := c.Media.UploadProductImage(10, MediaUpload{FileName: "back.png", Data: []byte("png data"), AltText: "Back"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(product.Images) != 2 || len(uploads) != 1 || len(fields) != 1 {
+		t.Errorf("UploadProductImage() = %+v, %d uploads, %d fields", product, len(uploads), len(fields))
+	}
+	if !strings.Contains(body, `"images":[{"id":40},{"id":55,"alt":"Back"}]`) {
+		t.Errorf("product update %s", body)
+	}
+}
+
+func TestMediaServiceOp_UploadVariationImage(t *testing.T) {
+	var uploads []string
+	var fields []mediaFields
+	var body string
+	c := newMediaTestClient(t, &uploads, &fields, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "PUT /wp-json/wc/v3/products/10/variations/12" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		fmt.Fprint(w, `{"id":12,"image":{"id":55}}`)
+	})
+
+	variation, err := c.Media.UploadVariationImage(10, 12, MediaUpload{FileName: "red.png", Data: []byte("png data"), Title: "Red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if variation.Image == nil || variation.Image.Id != 55 || len(uploads) != 1 {
+		t.Errorf("UploadVariationImage() = %+v, %d uploads", variation, len(uploads))
+	}
+	if !strings.Contains(body, `"image":{"id":55,"name":"Red"}`) {
+		t.Errorf("variation update %s", body)
+	}
+}
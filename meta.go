@@ -0,0 +1,175 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MetaDataList is the meta_data of a resource, with typed accessors by key:
+//
+//	var tracking string
+//	ok, err := order.MetaData.Decode("_tracking_number", &tracking)
+//	order.MetaData.Set("_tracking_number", "1Z999")
+//	order.MetaData.Delete("_legacy_flag")
+type MetaDataList []MetaData
+
+// metaTag is the struct tag mapping fields to meta keys, see MetaDataList.Unmarshal
+const metaTag = "wcmeta"
+
+// UnmarshalJSON keeps the unknown properties in Extra
+func (m *MetaData) UnmarshalJSON(data []byte) error {
+	type metaData MetaData
+	return unmarshalExtra(data, (*metaData)(m), &m.Extra)
+}
+
+// MarshalJSON sends a deleted entry, or an existing one without value, as "value": null so
+// WooCommerce deletes it, other entries with the properties of Extra
+func (m MetaData) MarshalJSON() ([]byte, error) {
+	type metaData MetaData
+	if (m.deleted || m.Value == nil) && m.ID != 0 {
+		return json.Marshal(struct {
+			ID    int64       `json:"id"`
+			Key   string      `json:"key,omitempty"`
+			Value interface{} `json:"value"`
+		}{ID: m.ID, Key: m.Key})
+	}
+	return marshalExtra(metaData(m), m.Extra)
+}
+
+// Deleted reports whether the entry was deleted with MetaDataList.Delete
+func (m MetaData) Deleted() bool {
+	return m.deleted
+}
+
+// Get returns the first entry with key
+func (l MetaDataList) Get(key string) (MetaData, bool) {
+	for _, m := range l {
+		if m.Key == key && !m.deleted {
+			return m, true
+		}
+	}
+	return MetaData{}, false
+}
+
+// Has reports whether an entry with key exists
+func (l MetaDataList) Has(key string) bool {
+	_, ok := l.Get(key)
+	return ok
+}
+
+// String returns the value of key as a string, or "" when missing or not a string
+func (l MetaDataList) String(key string) string {
+	m, _ := l.Get(key)
+	s, _ := m.Value.(string)
+	return s
+}
+
+// Decode decodes the value of key into v, a pointer, ok is false when key is missing.
+// Values stored as strings decode into numbers and booleans, e.g. "12" into an int.
+func (l MetaDataList) Decode(key string, v interface{}) (bool, error) {
+	m, ok := l.Get(key)
+	if !ok {
+		return false, nil
+	}
+	return true, decodeMetaValue(m.Value, v)
+}
+
+// Set sets the value of key, updating the existing entry or adding a new one. The list is
+// copied, so copies of it, such as the original of a Diff, are left unchanged.
+func (l *MetaDataList) Set(key string, value interface{}) {
+	list := make(MetaDataList, len(*l), len(*l)+1)
+	copy(list, *l)
+	*l = list
+	for i := range list {
+		if list[i].Key == key {
+			list[i].Value = value
+			list[i].deleted = false
+			return
+		}
+	}
+	*l = append(list, MetaData{Key: key, Value: value})
+}
+
+// Delete deletes every entry with key. Entries with an ID are kept, marked for deletion so
+// the next update deletes them, the others are removed from the list. Like Set, it copies
+// the list.
+func (l *MetaDataList) Delete(key string) {
+	list := make(MetaDataList, 0, len(*l))
+	for _, m := range *l {
+		if m.Key == key {
+			if m.ID == 0 {
+				continue
+			}
+			m.Value = nil
+			m.deleted = true
+		}
+		list = append(list, m)
+	}
+	*l = list
+}
+
+// Unmarshal sets the fields of the struct pointed to by v tagged `wcmeta:"<key>"` from the
+// entries with those keys, leaving the fields of missing keys unchanged.
+func (l MetaDataList) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("woocommerce: meta unmarshal into %T, want a struct pointer", v)
+	}
+	rv = rv.Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get(metaTag), ",")
+		if key == "" || key == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		if _, err := l.Decode(key, rv.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("woocommerce: meta %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Marshal sets the entries of the fields of the struct v tagged `wcmeta:"<key>"`. Add
+// ",omitempty" to the tag to delete the key instead of setting a zero value.
+func (l *MetaDataList) Marshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("woocommerce: meta marshal of %T, want a struct", v)
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get(metaTag)
+		if tag == "" || tag == "-" || !t.Field(i).IsExported() {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		field := rv.Field(i)
+		if opts == "omitempty" && field.IsZero() {
+			l.Delete(key)
+			continue
+		}
+		l.Set(key, field.Interface())
+	}
+	return nil
+}
+
+// decodeMetaValue converts a decoded JSON value into v through its JSON encoding
+func decodeMetaValue(value interface{}, v interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, v)
+	if s, ok := value.(string); ok && err != nil {
+		// numbers and booleans stored as strings
+		if json.Unmarshal([]byte(s), v) == nil {
+			return nil
+		}
+	}
+	return err
+}
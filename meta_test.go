@@ -0,0 +1,66 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMetaDataList(t *testing.T) {
+	var order Order
+	err := json.Unmarshal([]byte(`{"meta_data": [
+		{"id": 1, "key": "_tracking_number", "value": "1Z999"},
+		{"id": 2, "key": "_parcels", "value": "3"},
+		{"id": 3, "key": "_dimensions", "value": {"width": 10, "height": 20}},
+		{"id": 4, "key": "_legacy_flag", "value": "yes"}
+	]}`), &order)
+	if err != nil {
+		t.Fatalf("unmarshal fail: %v", err)
+	}
+
+	var shipment struct {
+		Tracking   string `wcmeta:"_tracking_number"`
+		Parcels    int    `wcmeta:"_parcels"`
+		Dimensions struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `wcmeta:"_dimensions"`
+		Carrier string `wcmeta:"_carrier,omitempty"`
+	}
+	if err := order.MetaData.Unmarshal(&shipment); err != nil {
+		t.Fatalf("meta unmarshal fail: %v", err)
+	}
+	if shipment.Tracking != "1Z999" || shipment.Parcels != 3 || shipment.Dimensions.Height != 20 {
+		t.Errorf("unexpected shipment: %+v", shipment)
+	}
+
+	shipment.Carrier = "UPS"
+	shipment.Parcels = 4
+	if err := order.MetaData.Marshal(shipment); err != nil {
+		t.Fatalf("meta marshal fail: %v", err)
+	}
+	order.MetaData.Delete("_legacy_flag")
+	if order.MetaData.Has("_legacy_flag") || order.MetaData.String("_carrier") != "UPS" {
+		t.Errorf("unexpected meta: %+v", order.MetaData)
+	}
+
+	data, _ := json.Marshal(order.MetaData)
+	want := `[{"id":1,"key":"_tracking_number","value":"1Z999"},{"id":2,"key":"_parcels","value":4},` +
+		`{"id":3,"key":"_dimensions","value":{"width":10,"height":20}},{"id":4,"key":"_legacy_flag","value":null},` +
+		`{"key":"_carrier","value":"UPS"}]`
+	if string(data) != want {
+		t.Errorf("meta = %s\nwant   %s", data, want)
+	}
+}
+
+func TestMetaDataList_CopyOnWrite(t *testing.T) {
+	original := MetaDataList{{ID: 1, Key: "_a", Value: "1"}, {ID: 2, Key: "_b", Value: "2"}}
+	changed := original
+	changed.Set("_a", "3")
+	changed.Delete("_b")
+	if original[0].Value != "1" || original[1].Value != "2" || original[1].deleted {
+		t.Errorf("original changed to %+v", original)
+	}
+	if changed[0].Value != "3" || !changed[1].deleted {
+		t.Errorf("changed = %+v", changed)
+	}
+}
@@ -0,0 +1,291 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount is a monetary value as WooCommerce encodes it, a decimal string such as "12.50".
+// It keeps the exact representation received, numbers included, so values round-trip
+// unchanged; use Money for arithmetic.
+type Amount string
+
+// UnmarshalJSON accepts decimal strings, JSON numbers and null
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*a = ""
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*a = Amount(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("woocommerce: invalid amount %s", data)
+	}
+	*a = Amount(n)
+	return nil
+}
+
+// Money parses the amount in currency, keeping its decimal places. An empty amount is zero.
+func (a Amount) Money(currency string) (Money, error) {
+	return ParseMoney(string(a), currency)
+}
+
+// ErrCurrencyMismatch is returned when combining Money of different currencies
+type ErrCurrencyMismatch struct {
+	A, B string
+}
+
+func (e ErrCurrencyMismatch) Error() string {
+	return fmt.Sprintf("woocommerce: currency mismatch %s and %s", e.A, e.B)
+}
+
+// Money is an exact decimal amount in a currency, stored as an integer number of units of
+// 10^-Decimals. Money values are immutable, the zero value is 0 without currency.
+type Money struct {
+	units    *big.Int
+	decimals int
+	currency string
+}
+
+// NewMoney returns units of 10^-decimals in currency, e.g. NewMoney(1250, 2, "EUR") is 12.50 EUR
+func NewMoney(units int64, decimals int, currency string) Money {
+	return Money{units: big.NewInt(units), decimals: decimals, currency: currency}
+}
+
+// maxMoneyExponent bounds the exponent ParseMoney accepts, larger ones would allocate
+// numbers far beyond any amount
+const maxMoneyExponent = 32
+
+// ParseMoney parses a decimal string such as "-12.50", "3" or "1e2" in currency. The decimal
+// places of s are kept, so "12.50" formats back as "12.50". An empty string is zero, exponents
+// are limited to ±32.
+func ParseMoney(s, currency string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{units: new(big.Int), currency: currency}, nil
+	}
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxMoneyExponent || e < -maxMoneyExponent {
+			return Money{}, fmt.Errorf("woocommerce: invalid amount %q", s)
+		}
+		exponent = e
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" || strings.ContainsAny(digits[1:], "+-") {
+		return Money{}, fmt.Errorf("woocommerce: invalid amount %q", s)
+	}
+	units, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Money{}, fmt.Errorf("woocommerce: invalid amount %q", s)
+	}
+	decimals := len(fracPart) - exponent
+	if decimals < 0 {
+		units.Mul(units, pow10(-decimals))
+		decimals = 0
+	}
+	return Money{units: units, decimals: decimals, currency: currency}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid amounts, for constants and tests
+func MustParseMoney(s, currency string) Money {
+	m, err := ParseMoney(s, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Currency returns the ISO 4217 currency code
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Decimals returns the number of decimal places
+func (m Money) Decimals() int {
+	return m.decimals
+}
+
+// Units returns the amount in units of 10^-Decimals, ok is false when it doesn't fit an int64
+func (m Money) Units() (units int64, ok bool) {
+	u := m.int()
+	return u.Int64(), u.IsInt64()
+}
+
+// Sign returns -1, 0 or +1
+func (m Money) Sign() int {
+	return m.int().Sign()
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{units: new(big.Int).Neg(m.int()), decimals: m.decimals, currency: m.currency}
+}
+
+// Add returns m + o, with the decimal places of the more precise operand
+func (m Money) Add(o Money) (Money, error) {
+	a, b, err := m.align(o)
+	if err != nil {
+		return Money{}, err
+	}
+	a.units.Add(a.units, b.units)
+	return a, nil
+}
+
+// Sub returns m - o, with the decimal places of the more precise operand
+func (m Money) Sub(o Money) (Money, error) {
+	a, b, err := m.align(o)
+	if err != nil {
+		return Money{}, err
+	}
+	a.units.Sub(a.units, b.units)
+	return a, nil
+}
+
+// MulInt returns m * n, e.g. a unit price times a quantity
+func (m Money) MulInt(n int64) Money {
+	return Money{units: new(big.Int).Mul(m.int(), big.NewInt(n)), decimals: m.decimals, currency: m.currency}
+}
+
+// Cmp compares m and o and returns -1, 0 or +1
+func (m Money) Cmp(o Money) (int, error) {
+	a, b, err := m.align(o)
+	if err != nil {
+		return 0, err
+	}
+	return a.units.Cmp(b.units), nil
+}
+
+// Equal reports whether m and o are the same amount in the same currency, regardless of
+// their decimal places
+func (m Money) Equal(o Money) bool {
+	c, err := m.Cmp(o)
+	return err == nil && c == 0
+}
+
+// Round returns m with the given decimal places, rounding half away from zero as WooCommerce
+// does. Rounding to more decimal places pads with zeros, negative decimals round to tens,
+// hundreds and so on, e.g. Round(-1) of 1234.56 is 1230.
+func (m Money) Round(decimals int) Money {
+	if decimals >= m.decimals {
+		return m.rescale(decimals)
+	}
+	div := pow10(m.decimals - decimals)
+	q, r := new(big.Int).QuoRem(m.int(), div, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(div) >= 0 {
+		if m.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if decimals < 0 {
+		return Money{units: q.Mul(q, pow10(-decimals)), currency: m.currency}
+	}
+	return Money{units: q, decimals: decimals, currency: m.currency}
+}
+
+// String formats the amount as a decimal string without currency, e.g. "-12.50"
+func (m Money) String() string {
+	u := m.int()
+	digits := new(big.Int).Abs(u).String()
+	if m.decimals > 0 {
+		if len(digits) <= m.decimals {
+			digits = strings.Repeat("0", m.decimals-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-m.decimals] + "." + digits[len(digits)-m.decimals:]
+	}
+	if u.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Amount returns m in WooCommerce's string encoding
+func (m Money) Amount() Amount {
+	return Amount(m.String())
+}
+
+// MarshalJSON encodes m as a decimal string, like WooCommerce amounts
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes an amount like Amount does, keeping the currency of m since the
+// encoding doesn't carry it
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var a Amount
+	if err := a.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	money, err := a.Money(m.currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+func (m Money) int() *big.Int {
+	if m.units == nil {
+		return new(big.Int)
+	}
+	return m.units
+}
+
+// rescale returns a copy of m with more decimal places
+func (m Money) rescale(decimals int) Money {
+	units := new(big.Int).Set(m.int())
+	if decimals > m.decimals {
+		units.Mul(units, pow10(decimals-m.decimals))
+	}
+	return Money{units: units, decimals: decimals, currency: m.currency}
+}
+
+// align returns copies of m and o with the same decimal places. Money without currency,
+// e.g. the zero value, takes the currency of the other operand.
+func (m Money) align(o Money) (Money, Money, error) {
+	currency := m.currency
+	switch {
+	case currency == "":
+		currency = o.currency
+	case o.currency != "" && o.currency != currency:
+		return Money{}, Money{}, ErrCurrencyMismatch{A: m.currency, B: o.currency}
+	}
+	decimals := m.decimals
+	if o.decimals > decimals {
+		decimals = o.decimals
+	}
+	a, b := m.rescale(decimals), o.rescale(decimals)
+	a.currency, b.currency = currency, currency
+	return a, b, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Money returns an amount of the order in the order's currency, e.g. order.Money(order.Total)
+func (o *Order) Money(a Amount) (Money, error) {
+	return a.Money(o.Currency)
+}
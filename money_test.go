@@ -0,0 +1,97 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAmount_UnmarshalJSON(t *testing.T) {
+	var item LineItem
+	if err := json.Unmarshal([]byte(`{"subtotal": "18.20", "total": null, "price": 9.1}`), &item); err != nil {
+		t.Fatalf("unmarshal fail: %v", err)
+	}
+	if item.SubTotal != "18.20" || item.Total != "" || item.Price != "9.1" {
+		t.Errorf("unexpected amounts: %+v", item)
+	}
+	data, _ := json.Marshal(item)
+	if !strings.Contains(string(data), `"subtotal":"18.20"`) || strings.Contains(string(data), `"total"`) {
+		t.Errorf("unexpected json: %s", data)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	order := &Order{Currency: "EUR", Total: "10.00", ShippingTotal: "4.995"}
+	total, err := order.Money(order.Total)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shipping, _ := order.Money(order.ShippingTotal)
+
+	sum, err := total.Add(shipping)
+	if err != nil || sum.String() != "14.995" || sum.Currency() != "EUR" {
+		t.Errorf("sum = %v, %v", sum, err)
+	}
+	if got := sum.Round(2).Amount(); got != "15.00" {
+		t.Errorf("round = %s, want 15.00", got)
+	}
+	if got := MustParseMoney("-0.125", "EUR").Round(2).String(); got != "-0.13" {
+		t.Errorf("round negative = %s, want -0.13", got)
+	}
+	for _, tt := range []struct {
+		amount   string
+		decimals int
+		want     string
+	}{
+		{"1234.56", -1, "1230"},
+		{"1235", -1, "1240"},
+		{"-1250.5", -2, "-1300"},
+		{"49.99", -2, "0"},
+	} {
+		if got := MustParseMoney(tt.amount, "EUR").Round(tt.decimals); got.String() != tt.want || got.Decimals() != 0 {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+		}
+	}
+	if got := MustParseMoney("2.5", "EUR").MulInt(3).String(); got != "7.5" {
+		t.Errorf("mul = %s, want 7.5", got)
+	}
+	if !MustParseMoney("10", "EUR").Equal(total) {
+		t.Errorf("10 should equal 10.00")
+	}
+	if c, _ := shipping.Cmp(total); c != -1 {
+		t.Errorf("cmp = %d, want -1", c)
+	}
+
+	_, err = total.Sub(MustParseMoney("1", "USD"))
+	var mismatch ErrCurrencyMismatch
+	if !errors.As(err, &mismatch) {
+		t.Errorf("expected currency mismatch, got %v", err)
+	}
+	for _, s := range []string{"1.2.3", "1e999999999", "1e-999999999"} {
+		if _, err := ParseMoney(s, "EUR"); err == nil {
+			t.Errorf("ParseMoney(%q) expected invalid amount error", s)
+		}
+	}
+	if got := MustParseMoney("1.5e2", "EUR").String(); got != "150" {
+		t.Errorf("1.5e2 = %s", got)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	price := MustParseMoney("-12.50", "EUR")
+	data, err := json.Marshal(price)
+	if err != nil || string(data) != `"-12.50"` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+	decoded := NewMoney(0, 0, "EUR")
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.String() != "-12.50" || decoded.Currency() != "EUR" {
+		t.Errorf("Unmarshal() = %v %s, %v", decoded, decoded.Currency(), err)
+	}
+	if err := json.Unmarshal([]byte(`4.5`), &decoded); err != nil || decoded.String() != "4.5" {
+		t.Errorf("Unmarshal(4.5) = %v, %v", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`"abc"`), &decoded); err == nil {
+		t.Error("Unmarshal(abc) expected invalid amount error")
+	}
+}
@@ -10,6 +10,7 @@ const (
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#order-notes
 type OrderNoteService interface {
 	Create(orderId int64, text string) (*OrderNote, error)
+	CreateCustomerNote(orderId int64, text string) (*OrderNote, error)
 	Get(orderId int64, noteId int64) (*OrderNote, error)
 	List(orderId int64, options interface{}) (*[]OrderNote, error)
 	Delete(orderId int64, noteId int64, options interface{}) (*OrderNote, error)
@@ -20,12 +21,13 @@ type OrderNoteService interface {
 type OrderNote struct {
 	ID             int64  `json:"id,omitempty"`
 	Author         string `json:"author,omitempty"`
-	DateCreated    string `json:"date_created,omitempty"`
-	DateCreatedGmt string `json:"date_created_gmt,omitempty"`
+	DateCreated    *Time  `json:"date_created,omitempty"`
+	DateCreatedGmt *Time  `json:"date_created_gmt,omitempty"`
 
 	Note         string `json:"note,omitempty"`
-	CustomerNote string `json:"customer_note,omitempty"`
+	CustomerNote bool   `json:"customer_note,omitempty"`
 	AddedByUser  bool   `json:"added_by_user,omitempty"`
+	Extra        Extra  `json:"-"`
 }
 
 type OrderNoteServiceOp struct {
@@ -42,6 +44,18 @@ func (n *OrderNoteServiceOp) Create(orderId int64, text string) (*OrderNote, err
 	return resource, err
 }
 
+// CreateCustomerNote adds a note that is shown to the customer, WooCommerce emails it to them as well
+func (n *OrderNoteServiceOp) CreateCustomerNote(orderId int64, text string) (*OrderNote, error) {
+	path := fmt.Sprintf("%s/%d/notes", orderNoteBasePath, orderId)
+	resource := new(OrderNote)
+	insertOrderNote := OrderNote{
+		Note:         text,
+		CustomerNote: true,
+	}
+	err := n.client.Post(path, insertOrderNote, resource)
+	return resource, err
+}
+
 func (n *OrderNoteServiceOp) Get(orderId int64, noteId int64) (*OrderNote, error) {
 	path := fmt.Sprintf("%s/%d/notes/%d", orderNoteBasePath, orderId, noteId)
 	resource := new(OrderNote)
@@ -29,3 +29,12 @@ func WithLog(logger LeveledLoggerInterface) Option {
 		c.log = logger
 	}
 }
+
+// WithWordPressAuth sets the WordPress user and application password used for the
+// WordPress REST API endpoints, e.g. media uploads
+func WithWordPressAuth(user, applicationPassword string) Option {
+	return func(c *Client) {
+		c.wpUser = user
+		c.wpPassword = applicationPassword
+	}
+}
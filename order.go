@@ -1,12 +1,18 @@
 package woocommerce
 
 import (
+	"encoding/json"
 	"fmt"
-	"net/http"
+	"strconv"
+	"strings"
 )
 
 const (
 	ordersBasePath = "orders"
+
+	// downloadPermissionsGrantedKey is the order meta WooCommerce checks before granting the
+	// download permissions of the order's downloadable items
+	downloadPermissionsGrantedKey = "_download_permissions_granted"
 )
 
 // OrderService is an interface for interfacing with the orders endpoints of woocommerce API
@@ -15,9 +21,18 @@ type OrderService interface {
 	Create(order Order) (*Order, error)
 	Get(orderId int64, options interface{}) (*Order, error)
 	List(options interface{}) ([]Order, error)
+	ListWithPages(options interface{}) ([]Order, *Pagination, error)
 	Update(order *Order) (*Order, error)
+	Patch(orderID int64, patch Patch) (*Order, error)
+	UpdateChanged(original, changed *Order, options *UpdateChangedOption) (*Order, error)
 	Delete(orderID int64, options interface{}) (*Order, error)
 	Batch(option OrderBatchOption) (*OrderBatchResource, error)
+	BatchAll(data OrderBatchOption, options *BatchOption) (*BatchResult[Order], error)
+	ResetDownloadPermissions(orderID int64) (*Order, error)
+	EmailTemplates(orderID int64) ([]OrderEmailTemplateInfo, error)
+	SendEmail(orderID int64, template OrderEmailTemplate, options *OrderActionOption) (*OrderActionResult, error)
+	SendOrderDetails(orderID int64, options *OrderActionOption) (*OrderActionResult, error)
+	Transition(orderID int64, to OrderStatus, note string) (*Order, error)
 }
 
 // OrderServiceOp handles communication with the order related methods of WooCommerce'API
@@ -35,25 +50,90 @@ type OrderServiceOp struct {
 // search	string	Limit results to those matching a string.
 // after	string	Limit response to resources published after a given ISO8601 compliant date.
 // before	string	Limit response to resources published before a given ISO8601 compliant date.
+// modified_after	string	Limit response to resources modified after a given ISO8601 compliant date.
+// modified_before	string	Limit response to resources modified before a given ISO8601 compliant date.
+// dates_are_gmt	boolean	Whether to consider GMT post dates when limiting response by published or modified date.
 // exclude	array	Ensure result set excludes specific IDs.
 // include	array	Limit result set to specific ids.
 // offset	integer	Offset the result set by a specific number of items.
 // order	string	Order sort attribute ascending or descending. Options: asc and desc. Default is desc.
-// orderby	string	Sort collection by object attribute. Options: date, id, include, title and slug. Default is date.
+// orderby	string	Sort collection by object attribute. Options: date, modified, id, include, title and slug. Default is date.
 // parent	array	Limit result set to those of particular parent IDs.
 // parent_exclude	array	Limit result set to all items except those of a particular parent ID.
 // status	array	Limit result set to orders assigned a specific status. Options: any, pending, processing, on-hold, completed, cancelled, refunded, failed and trash. Default is any.
 // customer	integer	Limit result set to orders assigned a specific customer.
 // product	integer	Limit result set to orders assigned a specific product.
 // dp	integer	Number of decimal points to use in each resource. Default is 2.
+// created_via	array	Limit result set to orders created via specific sources, e.g. checkout, admin or rest-api.
+// search_fields	array	Limit search to specific fields, e.g. billing_email or order_key.
 type OrderListOption struct {
 	ListOptions
-	Parent        []int64  `url:"parent,omitempty"`
-	ParentExclude []int64  `url:"parent_exclude,omitempty"`
-	Status        []string `url:"status,omitempty"`
-	Customer      int64    `url:"customer,omitempty"`
-	Product       int64    `url:"product,omitempty"`
-	Dp            int      `url:"id,omitempty"`
+	ModifiedAfter  Time          `url:"modified_after,omitempty"`
+	ModifiedBefore Time          `url:"modified_before,omitempty"`
+	DatesAreGMT    bool          `url:"dates_are_gmt,omitempty"`
+	Parent         []int64       `url:"parent,omitempty,comma"`
+	ParentExclude  []int64       `url:"parent_exclude,omitempty,comma"`
+	Status         []OrderStatus `url:"status,omitempty,comma"`
+	Customer       int64         `url:"customer,omitempty"`
+	Product        int64         `url:"product,omitempty"`
+	// Dp is the number of decimal points when DecimalPoints isn't set.
+	//
+	// Deprecated: use DecimalPoints, Dp can't request 0 decimal points.
+	Dp int `url:"-"`
+	// DecimalPoints is a pointer so 0 decimal points can be requested
+	DecimalPoints *int     `url:"dp,omitempty"`
+	CreatedVia    []string `url:"created_via,omitempty,comma"`
+	SearchFields  []string `url:"search_fields,omitempty,comma"`
+}
+
+// orderOrderbyValues are the accepted orderby values when listing orders
+var orderOrderbyValues = map[string]bool{"date": true, "modified": true, "id": true, "include": true, "title": true, "slug": true}
+
+// Validate rejects options the REST API would refuse or that can't match any order
+func (o OrderListOption) Validate() error {
+	if err := o.ListOptions.Validate(); err != nil {
+		return err
+	}
+	switch {
+	case o.Orderby != "" && !orderOrderbyValues[o.Orderby]:
+		return ListOptionError{Field: "orderby", Message: fmt.Sprintf("%q is not supported for orders", o.Orderby)}
+	case !o.ModifiedAfter.IsZero() && !o.ModifiedBefore.IsZero() && !o.ModifiedAfter.Before(o.ModifiedBefore.Time):
+		return ListOptionError{Field: "modified_after", Message: "must be before the modified_before date"}
+	case o.DecimalPoints != nil && *o.DecimalPoints < 0:
+		return ListOptionError{Field: "dp", Message: "must not be negative"}
+	case len(o.SearchFields) > 0 && o.Search == "":
+		return ListOptionError{Field: "search_fields", Message: "needs a search"}
+	}
+	if id, ok := overlap(o.Parent, o.ParentExclude); ok {
+		return ListOptionError{Field: "parent_exclude", Message: fmt.Sprintf("parent %d is also included", id)}
+	}
+	for _, status := range o.Status {
+		if status == OrderStatusAny && len(o.Status) > 1 {
+			return ListOptionError{Field: "status", Message: "any can't be combined with other statuses"}
+		}
+	}
+	return nil
+}
+
+// withDecimalPoints returns order list options with the deprecated Dp set as DecimalPoints
+func withDecimalPoints(options interface{}) interface{} {
+	var option OrderListOption
+	switch o := options.(type) {
+	case OrderListOption:
+		option = o
+	case *OrderListOption:
+		if o == nil {
+			return options
+		}
+		option = *o
+	default:
+		return options
+	}
+	if option.DecimalPoints == nil && option.Dp != 0 {
+		dp := option.Dp
+		option.DecimalPoints = &dp
+	}
+	return option
 }
 
 // OrderBatchOption setting  operate for order in batch way
@@ -80,19 +160,19 @@ type Order struct {
 	OrderKey           string          `json:"order_key,omitempty"`
 	CreatedVia         string          `json:"created_via,omitempty"`
 	Version            string          `json:"version,omitempty"`
-	Status             string          `json:"status,omitempty"`
+	Status             OrderStatus     `json:"status,omitempty"`
 	Currency           string          `json:"currency,omitempty"`
-	DateCreated        string          `json:"date_created,omitempty"`
-	DateCreatedGmt     string          `json:"date_created_gmt,omitempty"`
-	DateModified       string          `json:"date_modified,omitempty"`
-	DateModifiedGmt    string          `json:"date_modified_gmt,omitempty"`
-	DiscountsTotal     string          `json:"discount_total,omitempty"`
-	DiscountsTax       string          `json:"discount_tax,omitempty"`
-	ShippingTotal      string          `json:"shipping_total,omitempty"`
-	ShippingTax        string          `json:"shipping_tax,omitempty"`
-	CartTax            string          `json:"cart_tax,omitempty"`
-	Total              string          `json:"total,omitempty"`
-	TotalTax           string          `json:"total_tax,omitempty"`
+	DateCreated        *Time           `json:"date_created,omitempty"`
+	DateCreatedGmt     *Time           `json:"date_created_gmt,omitempty"`
+	DateModified       *Time           `json:"date_modified,omitempty"`
+	DateModifiedGmt    *Time           `json:"date_modified_gmt,omitempty"`
+	DiscountsTotal     Amount          `json:"discount_total,omitempty"`
+	DiscountsTax       Amount          `json:"discount_tax,omitempty"`
+	ShippingTotal      Amount          `json:"shipping_total,omitempty"`
+	ShippingTax        Amount          `json:"shipping_tax,omitempty"`
+	CartTax            Amount          `json:"cart_tax,omitempty"`
+	Total              Amount          `json:"total,omitempty"`
+	TotalTax           Amount          `json:"total_tax,omitempty"`
 	PricesIncludeTax   bool            `json:"prices_include_tax,omitempty"`
 	CustomerId         int64           `json:"customer_id,omitempty"`
 	CustomerIpAddress  string          `json:"customer_ip_address,omitempty"`
@@ -103,12 +183,12 @@ type Order struct {
 	PaymentMethod      string          `json:"payment_method,omitempty"`
 	PaymentMethodTitle string          `json:"payment_method_title,omitempty"`
 	TransactionId      string          `json:"transaction_id,omitempty"`
-	DatePaid           string          `json:"date_paid,omitempty"`
-	DatePaidGmt        string          `json:"date_paid_gmt,omitempty"`
-	DateCompleted      string          `json:"date_completed,omitempty"`
-	DateCompletedGmt   string          `json:"date_completed_gmt,omitempty"`
+	DatePaid           *Time           `json:"date_paid,omitempty"`
+	DatePaidGmt        *Time           `json:"date_paid_gmt,omitempty"`
+	DateCompleted      *Time           `json:"date_completed,omitempty"`
+	DateCompletedGmt   *Time           `json:"date_completed_gmt,omitempty"`
 	CartHash           string          `json:"cart_hash,omitempty"`
-	MetaData           []MetaData      `json:"meta_data,omitempty"`
+	MetaData           MetaDataList    `json:"meta_data,omitempty"`
 	LineItems          []LineItem      `json:"line_items,omitempty"`
 	TaxLines           []TaxLine       `json:"tax_lines,omitempty"`
 	ShippingLines      []ShippingLines `json:"shipping_lines,omitempty"`
@@ -116,17 +196,27 @@ type Order struct {
 	CouponLines        []CouponLine    `json:"coupon_lines,omitempty"`
 	Refunds            []Refund        `json:"refunds,omitempty"`
 	CurrencySymbol     string          `json:"currency_symbol,omitempty"`
+	PaymentUrl         string          `json:"payment_url,omitempty"`
+	IsEditable         bool            `json:"is_editable,omitempty"`
+	NeedsPayment       bool            `json:"needs_payment,omitempty"`
+	NeedsProcessing    bool            `json:"needs_processing,omitempty"`
 	Links              Links           `json:"_links"`
 	SetPaid            bool            `json:"set_paid,omitempty"`
+	Extra              Extra           `json:"-"`
 }
 
+// Links are the _links of a resource. Customer is set on orders of registered customers, Up
+// on resources nested under another, e.g. a variation under its product.
 type Links struct {
-	Self []struct {
-		Href string `json:"href"`
-	} `json:"self"`
-	Collection []struct {
-		Href string `json:"href"`
-	} `json:"collection"`
+	Self       []Link `json:"self,omitempty"`
+	Collection []Link `json:"collection,omitempty"`
+	Customer   []Link `json:"customer,omitempty"`
+	Up         []Link `json:"up,omitempty"`
+}
+
+type Link struct {
+	Href        string                 `json:"href"`
+	TargetHints map[string]interface{} `json:"targetHints,omitempty"`
 }
 
 type Billing struct {
@@ -141,6 +231,7 @@ type Billing struct {
 	Country   string `json:"country,omitempty"`
 	Email     string `json:"email,omitempty"`
 	Phone     string `json:"phone,omitempty"`
+	Extra     Extra  `json:"-"`
 }
 
 type Shipping struct {
@@ -153,130 +244,204 @@ type Shipping struct {
 	State     string `json:"state,omitempty"`
 	PostCode  string `json:"postcode,omitempty"`
 	Country   string `json:"country,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	Extra     Extra  `json:"-"`
 }
 
 type LineItem struct {
-	ID          int64      `json:"id,omitempty"`
-	Name        string     `json:"name,omitempty"`
-	ProductID   int64      `json:"product_id,omitempty"`
-	VariantID   int64      `json:"variant_id,omitempty"`
-	Quantity    int        `json:"quantity,omitempty"`
-	TaxClass    string     `json:"tax_class,omitempty"`
-	SubTotal    string     `json:"subtotal,omitempty"`
-	SubtotalTax string     `json:"subtotal_tax,omitempty"`
-	Total       string     `json:"total,omitempty"`
-	TotalTax    string     `json:"total_tax,omitempty"`
-	Taxes       []TaxLine  `json:"taxes,omitempty"`
-	MetaData    []MetaData `json:"meta_data,omitempty"`
-	SKU         string     `json:"sku,omitempty"`
-	Price       float64    `json:"price,omitempty"`
+	ID          int64          `json:"id,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	ProductID   int64          `json:"product_id,omitempty"`
+	VariationID int64          `json:"variation_id,omitempty"`
+	Quantity    int            `json:"quantity,omitempty"`
+	TaxClass    string         `json:"tax_class,omitempty"`
+	SubTotal    Amount         `json:"subtotal,omitempty"`
+	SubtotalTax Amount         `json:"subtotal_tax,omitempty"`
+	Total       Amount         `json:"total,omitempty"`
+	TotalTax    Amount         `json:"total_tax,omitempty"`
+	Taxes       []ItemTax      `json:"taxes,omitempty"`
+	MetaData    MetaDataList   `json:"meta_data,omitempty"`
+	SKU         string         `json:"sku,omitempty"`
+	Price       Amount         `json:"price,omitempty"`
+	Image       *LineItemImage `json:"image,omitempty"`
+	ParentName  *string        `json:"parent_name,omitempty"`
+	Extra       Extra          `json:"-"`
+}
+
+// ItemTax is the tax of a line item, shipping or fee line for one tax rate, ID is the rate ID
+type ItemTax struct {
+	ID       int64  `json:"id,omitempty"`
+	Total    Amount `json:"total,omitempty"`
+	Subtotal Amount `json:"subtotal,omitempty"`
+	Extra    Extra  `json:"-"`
+}
+
+// LineItemImage is the product image of a line item, ID is 0 for products without image
+type LineItemImage struct {
+	ID  int64  `json:"id,omitempty"`
+	Src string `json:"src,omitempty"`
+}
+
+// UnmarshalJSON accepts the "" id WooCommerce sends for products without image
+func (i *LineItemImage) UnmarshalJSON(data []byte) error {
+	var image struct {
+		ID  json.RawMessage `json:"id"`
+		Src string          `json:"src"`
+	}
+	if err := json.Unmarshal(data, &image); err != nil {
+		return err
+	}
+	*i = LineItemImage{Src: image.Src}
+	id := strings.Trim(string(image.ID), `"`)
+	if id == "" || id == "null" {
+		return nil
+	}
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("woocommerce: invalid line item image id %s", image.ID)
+	}
+	i.ID = parsed
+	return nil
 }
 
 type TaxLine struct {
-	ID               int64      `json:"id,omitempty"`
-	RateCode         string     `json:"rate_code,omitempty"`
-	RateId           int64      `json:"rate_id,omitempty"`
-	Label            string     `json:"label,omitempty"`
-	Compound         bool       `json:"compound,omitempty"`
-	TaxTotal         string     `json:"tax_total"`
-	ShippingTaxTotal string     `json:"shipping_tax_total,omitempty"`
-	MetaData         []MetaData `json:"meta_data,omitempty"`
+	ID               int64        `json:"id,omitempty"`
+	RateCode         string       `json:"rate_code,omitempty"`
+	RateId           int64        `json:"rate_id,omitempty"`
+	Label            string       `json:"label,omitempty"`
+	Compound         bool         `json:"compound,omitempty"`
+	TaxTotal         Amount       `json:"tax_total,omitempty"`
+	ShippingTaxTotal Amount       `json:"shipping_tax_total,omitempty"`
+	RatePercent      float64      `json:"rate_percent,omitempty"`
+	MetaData         MetaDataList `json:"meta_data,omitempty"`
+	Extra            Extra        `json:"-"`
 }
 
 type MetaData struct {
 	ID    int64       `json:"id,omitempty"`
 	Key   string      `json:"key,omitempty"`
 	Value interface{} `json:"value,omitempty"`
+	// DisplayKey and DisplayValue are the formatted key and value of line item meta, read-only
+	DisplayKey   string      `json:"display_key,omitempty"`
+	DisplayValue interface{} `json:"display_value,omitempty"`
+	Extra        Extra       `json:"-"`
+
+	deleted bool
 }
 
 type FeeLine struct {
-	ID        int64      `json:"id,omitempty"`
-	Name      string     `json:"name,omitempty"`
-	TaxClass  string     `json:"tax_class,omitempty"`
-	TaxStatus string     `json:"tax_status,omitempty"`
-	Total     string     `json:"total,omitempty"`
-	TotalTax  string     `json:"total_tax,omitempty"`
-	Taxes     []TaxLine  `json:"taxes,omitempty"`
-	MetaData  []MetaData `json:"meta_data,omitempty"`
+	ID        int64        `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	TaxClass  string       `json:"tax_class,omitempty"`
+	TaxStatus string       `json:"tax_status,omitempty"`
+	Total     Amount       `json:"total,omitempty"`
+	TotalTax  Amount       `json:"total_tax,omitempty"`
+	Taxes     []ItemTax    `json:"taxes,omitempty"`
+	MetaData  MetaDataList `json:"meta_data,omitempty"`
+	Extra     Extra        `json:"-"`
 }
 
 type Refund struct {
 	ID     int64  `json:"id,omitempty"`
 	Reason string `json:"reason,omitempty"`
-	Total  string `json:"total,omitempty"`
+	Total  Amount `json:"total,omitempty"`
+	Extra  Extra  `json:"-"`
 }
 
 type ShippingLines struct {
-	ID          int64      `json:"id,omitempty"`
-	MethodTitle string     `json:"method_title,omitempty"`
-	MethodID    string     `json:"method_id,omitempty"`
-	Total       string     `json:"total,omitempty"`
-	TotalTax    string     `json:"total_tax,omitempty"`
-	Taxes       []TaxLine  `json:"tax_lines,omitempty"`
-	MetaData    []MetaData `json:"meta_data,omitempty"`
+	ID          int64        `json:"id,omitempty"`
+	MethodTitle string       `json:"method_title,omitempty"`
+	MethodID    string       `json:"method_id,omitempty"`
+	InstanceID  string       `json:"instance_id,omitempty"`
+	Total       Amount       `json:"total,omitempty"`
+	TotalTax    Amount       `json:"total_tax,omitempty"`
+	Taxes       []ItemTax    `json:"taxes,omitempty"`
+	MetaData    MetaDataList `json:"meta_data,omitempty"`
+	Extra       Extra        `json:"-"`
 }
 
 type CouponLine struct {
-	ID          int64      `json:"id,omitempty"`
-	Code        string     `json:"code,omitempty"`
-	Discount    string     `json:"discount,omitempty"`
-	DiscountTax string     `json:"discount_tax,omitempty"`
-	MetaData    []MetaData `json:"meta_data,omitempty"`
+	ID          int64        `json:"id,omitempty"`
+	Code        string       `json:"code,omitempty"`
+	Discount    Amount       `json:"discount,omitempty"`
+	DiscountTax Amount       `json:"discount_tax,omitempty"`
+	MetaData    MetaDataList `json:"meta_data,omitempty"`
+	// DiscountType, NominalAmount and FreeShipping describe the applied coupon, WooCommerce 8.7+
+	DiscountType  string  `json:"discount_type,omitempty"`
+	NominalAmount float64 `json:"nominal_amount,omitempty"`
+	FreeShipping  bool    `json:"free_shipping,omitempty"`
+	Extra         Extra   `json:"-"`
 }
 
 func (o *OrderServiceOp) List(options interface{}) ([]Order, error) {
-	orders, err := o.ListWithPagination(options)
+	orders, _, err := o.ListWithPages(options)
 	return orders, err
 }
 
-// ListWithPagination lists products and return pagination to retrieve next/previous results.
+// ListWithPagination lists orders
+//
+// Deprecated: ListWithPagination doesn't return the pagination, use ListWithPages.
 func (o *OrderServiceOp) ListWithPagination(options interface{}) ([]Order, error) {
-	path := fmt.Sprintf("%s", ordersBasePath)
-	resource := make([]Order, 0)
-	headers := http.Header{}
-	headers, err := o.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, err
-	}
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-	fmt.Println(linkHeader)
-	// pagination, err := extractPagination(linkHeader)
-	// if err != nil {
-	// 	return nil, nil, err
-	// }
+	return o.List(options)
+}
 
-	return resource, err
+// ListWithPages lists orders and returns pagination to retrieve next/previous results.
+func (o *OrderServiceOp) ListWithPages(options interface{}) ([]Order, *Pagination, error) {
+	options = withDecimalPoints(options)
+	if err := validateListOptions(options); err != nil {
+		return nil, nil, err
+	}
+	return o.resource().List(options)
 }
 
 func (o *OrderServiceOp) Create(order Order) (*Order, error) {
-	path := fmt.Sprintf("%s", ordersBasePath)
-	resource := new(Order)
-
-	err := o.client.Post(path, order, &resource)
-	return resource, err
+	return o.resource().Create(order)
 }
 
 // Get individual order
 func (o *OrderServiceOp) Get(orderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, orderID)
-	resource := new(Order)
-	err := o.client.Get(path, resource, options)
-	return resource, err
+	return o.resource().Get(orderID, options)
 }
 
 func (o *OrderServiceOp) Update(order *Order) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, order.ID)
-	resource := new(Order)
-	err := o.client.Put(path, order, &resource)
-	return resource, err
+	return o.resource().Update(order.ID, order)
+}
+
+// Patch updates the listed properties of an order, see Patch
+func (o *OrderServiceOp) Patch(orderID int64, patch Patch) (*Order, error) {
+	return o.resource().Patch(orderID, patch)
+}
+
+// UpdateChanged updates an order with the properties of changed that differ from original,
+// the order as fetched, see Diff. Make changed with original.Clone(). Nothing is sent when they
+// are equal and the error is ErrNoChanges.
+func (o *OrderServiceOp) UpdateChanged(original, changed *Order, options *UpdateChangedOption) (*Order, error) {
+	patch, err := diffForUpdate(original.ID, changed.ID, original, changed)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return changed, ErrNoChanges
+	}
+	if options != nil && options.CheckModified {
+		current, err := o.Get(original.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkModified(original.DateModifiedGmt, current.DateModifiedGmt); err != nil {
+			return current, err
+		}
+	}
+	return o.Patch(original.ID, patch)
+}
+
+// Clone returns a deep copy of the order, to change and pass to UpdateChanged with the original
+func (o *Order) Clone() *Order {
+	return cloneOf(o)
 }
 
 func (o *OrderServiceOp) Delete(orderID int64, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%d", ordersBasePath, orderID)
-	resource := new(Order)
-	err := o.client.Delete(path, options, &resource)
-	return resource, err
+	return o.resource().Delete(orderID, options)
 }
 
 func (o *OrderServiceOp) Batch(data OrderBatchOption) (*OrderBatchResource, error) {
@@ -285,3 +450,25 @@ func (o *OrderServiceOp) Batch(data OrderBatchOption) (*OrderBatchResource, erro
 	err := o.client.Post(path, data, &resource)
 	return resource, err
 }
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each order
+func (o *OrderServiceOp) BatchAll(data OrderBatchOption, options *BatchOption) (*BatchResult[Order], error) {
+	return o.resource().Batch(BatchRequest[Order](data), options)
+}
+
+func (o *OrderServiceOp) resource() *Resource[Order] {
+	return NewResource[Order](o.client, ordersBasePath)
+}
+
+// ResetDownloadPermissions clears the "download permissions granted" flag of the order, an
+// order meta, so WooCommerce grants its download permissions again, with the current
+// download_limit and download_expiry of the products, the next time the order moves to
+// processing or completed. Permissions already granted are kept as they are.
+//
+// Extending access isn't supported: the REST API exposes download permissions read-only, their
+// access_expires and downloads_remaining can't be changed through it.
+func (o *OrderServiceOp) ResetDownloadPermissions(orderID int64) (*Order, error) {
+	return o.Patch(orderID, Patch{
+		"meta_data": []MetaData{{Key: downloadPermissionsGrantedKey, Value: "no"}},
+	})
+}
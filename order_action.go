@@ -0,0 +1,132 @@
+package woocommerce
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// orderActionsBasePath is the base of the order actions endpoints WooCommerce registers:
+	// email_templates, send_email and send_order_details
+	orderActionsBasePath = "orders/%d/actions"
+
+	// restNoRouteCode is the error code WordPress answers with when an endpoint is not registered,
+	// e.g. on stores running a WooCommerce version without order actions.
+	restNoRouteCode = "rest_no_route"
+
+	defaultOrderActionNote = "The details of your order are available in your account."
+)
+
+// OrderEmailTemplate identifies a WooCommerce email that can be sent for an order
+type OrderEmailTemplate string
+
+const (
+	OrderEmailNewOrder               OrderEmailTemplate = "new_order"
+	OrderEmailCancelledOrder         OrderEmailTemplate = "cancelled_order"
+	OrderEmailFailedOrder            OrderEmailTemplate = "failed_order"
+	OrderEmailCustomerOnHoldOrder    OrderEmailTemplate = "customer_on_hold_order"
+	OrderEmailCustomerProcessing     OrderEmailTemplate = "customer_processing_order"
+	OrderEmailCustomerCompleted      OrderEmailTemplate = "customer_completed_order"
+	OrderEmailCustomerRefunded       OrderEmailTemplate = "customer_refunded_order"
+	OrderEmailCustomerInvoice        OrderEmailTemplate = "customer_invoice"
+	OrderEmailCustomerNote           OrderEmailTemplate = "customer_note"
+	OrderEmailCustomerCancelledOrder OrderEmailTemplate = "customer_cancelled_order"
+	OrderEmailCustomerFailedOrder    OrderEmailTemplate = "customer_failed_order"
+)
+
+// adminOrderEmails are the templates sent to the store rather than the customer, a customer
+// note can't replace them
+var adminOrderEmails = map[OrderEmailTemplate]bool{
+	OrderEmailNewOrder:       true,
+	OrderEmailCancelledOrder: true,
+	OrderEmailFailedOrder:    true,
+}
+
+// OrderEmailTemplateInfo describes an email template available for an order
+type OrderEmailTemplateInfo struct {
+	ID          OrderEmailTemplate `json:"id,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// OrderActionOption configures the order email actions.
+// Email overrides the recipient, ForceEmailUpdate stores it as the order's billing email.
+// FallbackNote is the customer note added on stores without order actions when the email is
+// for the customer, a generic message is used when it is empty.
+type OrderActionOption struct {
+	Email            string `json:"email,omitempty"`
+	ForceEmailUpdate bool   `json:"force_email_update,omitempty"`
+	FallbackNote     string `json:"-"`
+}
+
+// OrderActionResult is the outcome of an order action. When the store doesn't support order
+// actions, Note holds the customer note that was added instead.
+type OrderActionResult struct {
+	Message string     `json:"message,omitempty"`
+	Note    *OrderNote `json:"-"`
+}
+
+// orderActionRequest is the request body of the order email actions
+type orderActionRequest struct {
+	TemplateID OrderEmailTemplate `json:"template_id,omitempty"`
+	OrderActionOption
+}
+
+// EmailTemplates lists the email templates that can be sent for an order
+func (o *OrderServiceOp) EmailTemplates(orderID int64) ([]OrderEmailTemplateInfo, error) {
+	path := fmt.Sprintf(orderActionsBasePath+"/email_templates", orderID)
+	resource := make([]OrderEmailTemplateInfo, 0)
+	err := o.client.Get(path, &resource, nil)
+	return resource, err
+}
+
+// SendEmail sends the given email template for an order, e.g. to resend an invoice
+func (o *OrderServiceOp) SendEmail(orderID int64, template OrderEmailTemplate, options *OrderActionOption) (*OrderActionResult, error) {
+	path := fmt.Sprintf(orderActionsBasePath+"/send_email", orderID)
+	data := orderActionRequest{TemplateID: template}
+	if options != nil {
+		data.OrderActionOption = *options
+	}
+	return o.doAction(orderID, path, data, !adminOrderEmails[template])
+}
+
+// SendOrderDetails emails the order details to the customer
+func (o *OrderServiceOp) SendOrderDetails(orderID int64, options *OrderActionOption) (*OrderActionResult, error) {
+	path := fmt.Sprintf(orderActionsBasePath+"/send_order_details", orderID)
+	data := orderActionRequest{}
+	if options != nil {
+		data.OrderActionOption = *options
+	}
+	return o.doAction(orderID, path, data, true)
+}
+
+// doAction posts an order action. When the store doesn't have the order actions endpoints it
+// falls back to a customer note if fallback is set, and fails otherwise.
+func (o *OrderServiceOp) doAction(orderID int64, path string, data orderActionRequest, fallback bool) (*OrderActionResult, error) {
+	resource := new(OrderActionResult)
+	err := o.client.Post(path, data, resource)
+	if err == nil || !fallback || !isNoRouteError(err) {
+		return resource, err
+	}
+
+	text := data.FallbackNote
+	if text == "" {
+		text = defaultOrderActionNote
+	}
+	note, err := o.client.OrderNote.CreateCustomerNote(orderID, text)
+	if err != nil {
+		return nil, err
+	}
+	resource.Note = note
+	return resource, nil
+}
+
+// isNoRouteError reports whether err means the requested endpoint doesn't exist on the store
+func isNoRouteError(err error) bool {
+	var respErr ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	return respErr.Status == http.StatusNotFound && respErr.Code == restNoRouteCode
+}
@@ -0,0 +1,258 @@
+package woocommerce
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OrderBuilder builds an Order for OrderService.Create, validating it and resolving line items
+// given by SKU through a ProductService:
+//
+//	order, err := woocommerce.NewOrderBuilder(client.Product).
+//		Customer(26).
+//		Billing(billing).
+//		AddProduct(93, 2).
+//		AddSKU("hoodie-blue-m", 1, woocommerce.LinePrice("39.90")).
+//		AddShipping("flat_rate", "Flat Rate", "10.00").
+//		SetPaid(true).
+//		Create(client.Order)
+//
+// Setters record the first error, returned by Build.
+type OrderBuilder struct {
+	products ProductService
+	order    Order
+	lines    []orderBuilderLine
+	err      error
+}
+
+// orderBuilderLine is a line item, resolved from its SKU by Build when set
+type orderBuilderLine struct {
+	item  LineItem
+	sku   string
+	price Amount
+}
+
+// LineItemOption configures a line item added to an OrderBuilder
+type LineItemOption func(line *orderBuilderLine)
+
+// LinePrice sets the unit price of a line item, for prices differing from the catalog. The
+// builder sends the line's subtotal and total, price times quantity, which WooCommerce uses
+// instead of the product price.
+func LinePrice(unitPrice Amount) LineItemOption {
+	return func(line *orderBuilderLine) {
+		line.price = unitPrice
+	}
+}
+
+// LineMeta adds a meta data entry to a line item
+func LineMeta(key string, value interface{}) LineItemOption {
+	return func(line *orderBuilderLine) {
+		line.item.MetaData.Set(key, value)
+	}
+}
+
+// NewOrderBuilder returns an empty builder, products resolves the SKUs of AddSKU and may be
+// nil when no SKU is used
+func NewOrderBuilder(products ProductService) *OrderBuilder {
+	return &OrderBuilder{products: products}
+}
+
+func (b *OrderBuilder) Status(status OrderStatus) *OrderBuilder {
+	b.order.Status = status
+	return b
+}
+
+func (b *OrderBuilder) Currency(currency string) *OrderBuilder {
+	b.order.Currency = currency
+	return b
+}
+
+func (b *OrderBuilder) Customer(customerID int64) *OrderBuilder {
+	b.order.CustomerId = customerID
+	return b
+}
+
+func (b *OrderBuilder) CustomerNote(note string) *OrderBuilder {
+	b.order.CustomerNote = note
+	return b
+}
+
+func (b *OrderBuilder) Billing(billing Billing) *OrderBuilder {
+	b.order.Billing = &billing
+	return b
+}
+
+func (b *OrderBuilder) Shipping(shipping Shipping) *OrderBuilder {
+	b.order.Shipping = &shipping
+	return b
+}
+
+func (b *OrderBuilder) PaymentMethod(id, title string) *OrderBuilder {
+	b.order.PaymentMethod = id
+	b.order.PaymentMethodTitle = title
+	return b
+}
+
+// SetPaid marks the order paid on creation, moving it to processing and reducing stock
+func (b *OrderBuilder) SetPaid(paid bool) *OrderBuilder {
+	b.order.SetPaid = paid
+	return b
+}
+
+func (b *OrderBuilder) Meta(key string, value interface{}) *OrderBuilder {
+	b.order.MetaData.Set(key, value)
+	return b
+}
+
+// AddProduct adds a line item of a simple product
+func (b *OrderBuilder) AddProduct(productID int64, quantity int, opts ...LineItemOption) *OrderBuilder {
+	if productID <= 0 {
+		b.fail(fmt.Errorf("woocommerce: order line item with product id %d", productID))
+	}
+	return b.addLine(orderBuilderLine{item: LineItem{ProductID: productID, Quantity: quantity}}, opts)
+}
+
+// AddVariation adds a line item of a variation of a variable product
+func (b *OrderBuilder) AddVariation(productID, variationID int64, quantity int, opts ...LineItemOption) *OrderBuilder {
+	if productID <= 0 || variationID <= 0 {
+		b.fail(fmt.Errorf("woocommerce: order line item with product id %d and variation id %d", productID, variationID))
+	}
+	item := LineItem{ProductID: productID, VariationID: variationID, Quantity: quantity}
+	return b.addLine(orderBuilderLine{item: item}, opts)
+}
+
+// AddSKU adds a line item of the product or variation with sku, looked up by Build
+func (b *OrderBuilder) AddSKU(sku string, quantity int, opts ...LineItemOption) *OrderBuilder {
+	if sku == "" {
+		b.fail(errors.New("woocommerce: order line item with empty sku"))
+	}
+	return b.addLine(orderBuilderLine{sku: sku, item: LineItem{Quantity: quantity}}, opts)
+}
+
+// AddShipping adds a shipping line, methodID is e.g. "flat_rate" or "free_shipping"
+func (b *OrderBuilder) AddShipping(methodID, title string, total Amount) *OrderBuilder {
+	if methodID == "" {
+		b.fail(errors.New("woocommerce: order shipping line without method id"))
+	}
+	b.validAmount("shipping total", total)
+	b.order.ShippingLines = append(b.order.ShippingLines, ShippingLines{MethodID: methodID, MethodTitle: title, Total: total})
+	return b
+}
+
+// AddFee adds a fee line, a negative total is a discount
+func (b *OrderBuilder) AddFee(name string, total Amount) *OrderBuilder {
+	if name == "" {
+		b.fail(errors.New("woocommerce: order fee line without name"))
+	}
+	if _, err := ParseMoney(string(total), ""); err != nil {
+		b.fail(fmt.Errorf("woocommerce: order fee %s: %w", name, err))
+	}
+	b.order.FeeLines = append(b.order.FeeLines, FeeLine{Name: name, Total: total})
+	return b
+}
+
+// AddCoupon applies a coupon code, WooCommerce computes its discount
+func (b *OrderBuilder) AddCoupon(code string) *OrderBuilder {
+	if strings.TrimSpace(code) == "" {
+		b.fail(errors.New("woocommerce: order coupon line without code"))
+	}
+	b.order.CouponLines = append(b.order.CouponLines, CouponLine{Code: code})
+	return b
+}
+
+// Build validates the order and resolves the SKUs of its line items
+func (b *OrderBuilder) Build() (*Order, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.lines) == 0 {
+		return nil, errors.New("woocommerce: order without line items")
+	}
+	if b.order.Billing != nil && b.order.Billing.Email != "" && !strings.Contains(b.order.Billing.Email, "@") {
+		return nil, fmt.Errorf("woocommerce: invalid billing email %q", b.order.Billing.Email)
+	}
+
+	order := b.order
+	order.LineItems = make([]LineItem, 0, len(b.lines))
+	for _, line := range b.lines {
+		item := line.item
+		if line.sku != "" {
+			if err := b.resolveSKU(line.sku, &item); err != nil {
+				return nil, err
+			}
+		}
+		if line.price != "" {
+			price, err := ParseMoney(string(line.price), order.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("woocommerce: line item price: %w", err)
+			}
+			total := price.MulInt(int64(item.Quantity)).Amount()
+			item.SubTotal, item.Total = total, total
+		}
+		order.LineItems = append(order.LineItems, item)
+	}
+	return &order, nil
+}
+
+// Create builds the order and creates it with svc
+func (b *OrderBuilder) Create(svc OrderService) (*Order, error) {
+	order, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return svc.Create(*order)
+}
+
+func (b *OrderBuilder) addLine(line orderBuilderLine, opts []LineItemOption) *OrderBuilder {
+	if line.item.Quantity <= 0 {
+		b.fail(fmt.Errorf("woocommerce: order line item with quantity %d", line.item.Quantity))
+	}
+	for _, opt := range opts {
+		opt(&line)
+	}
+	if line.price != "" {
+		b.validAmount("line item price", line.price)
+	}
+	b.lines = append(b.lines, line)
+	return b
+}
+
+// resolveSKU sets the product and variation of a line item from its SKU
+func (b *OrderBuilder) resolveSKU(sku string, item *LineItem) error {
+	if b.products == nil {
+		return fmt.Errorf("woocommerce: no product service to resolve sku %q", sku)
+	}
+	products, err := b.products.List(ProductListOptions{SKU: sku})
+	if err != nil {
+		return fmt.Errorf("woocommerce: resolve sku %q: %w", sku, err)
+	}
+	for _, product := range products {
+		if product.Sku != sku {
+			continue
+		}
+		if product.Type == "variation" && product.ParentId != 0 {
+			item.ProductID, item.VariationID = product.ParentId, product.ID
+		} else {
+			item.ProductID = product.ID
+		}
+		return nil
+	}
+	return fmt.Errorf("woocommerce: no product with sku %q", sku)
+}
+
+// validAmount records an error for negative or malformed amounts
+func (b *OrderBuilder) validAmount(name string, amount Amount) {
+	m, err := ParseMoney(string(amount), "")
+	if err != nil {
+		b.fail(fmt.Errorf("woocommerce: order %s: %w", name, err))
+	} else if m.Sign() < 0 {
+		b.fail(fmt.Errorf("woocommerce: negative order %s %s", name, amount))
+	}
+}
+
+func (b *OrderBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,99 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// fakeProductService is an in-memory catalog, resolving SKUs and recording patches
+type fakeProductService struct {
+	ProductService
+	products []Product
+	patches  []Patch
+}
+
+func (f *fakeProductService) List(options interface{}) ([]Product, error) {
+	sku := options.(ProductListOptions).SKU
+	found := make([]Product, 0)
+	for _, p := range f.products {
+		if p.Sku == sku {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeProductService) Create(product Product) (*Product, error) {
+	if err := product.Validate(); err != nil {
+		return nil, err
+	}
+	product.ID = int64(100 + len(f.products))
+	f.products = append(f.products, product)
+	return &product, nil
+}
+
+func (f *fakeProductService) Get(productID int64, options interface{}) (*Product, error) {
+	for _, p := range f.products {
+		if p.ID == productID {
+			return &p, nil
+		}
+	}
+	return nil, ResponseError{Status: 404, Code: "woocommerce_rest_product_invalid_id"}
+}
+
+func (f *fakeProductService) Patch(productID int64, patch Patch) (*Product, error) {
+	for i := range f.products {
+		if f.products[i].ID == productID {
+			f.patches = append(f.patches, patch)
+			if err := applyProductChanges(&f.products[i], patch); err != nil {
+				return nil, err
+			}
+			return &f.products[i], nil
+		}
+	}
+	return nil, ResponseError{Status: 404, Code: "woocommerce_rest_product_invalid_id"}
+}
+
+func TestOrderBuilder(t *testing.T) {
+	products := &fakeProductService{products: []Product{
+		{ID: 93, Sku: "mug"},
+		{ID: 23, ParentId: 22, Type: "variation", Sku: "hoodie-m"},
+	}}
+
+	order, err := NewOrderBuilder(products).
+		Currency("EUR").
+		Customer(26).
+		Billing(Billing{FirstName: "John", Email: "john@example.com"}).
+		AddSKU("mug", 2).
+		AddSKU("hoodie-m", 3, LinePrice("19.99"), LineMeta("gift", "yes")).
+		AddShipping("flat_rate", "Flat Rate", "10.00").
+		AddCoupon("summer").
+		SetPaid(true).
+		Build()
+	if err != nil {
+		t.Fatalf("build fail: %v", err)
+	}
+	data, _ := json.Marshal(order.LineItems)
+	want := `[{"product_id":93,"quantity":2},` +
+		`{"product_id":22,"variation_id":23,"quantity":3,"subtotal":"59.97","total":"59.97","meta_data":[{"key":"gift","value":"yes"}]}]`
+	if string(data) != want {
+		t.Errorf("line items = %s\nwant         %s", data, want)
+	}
+	if !order.SetPaid || order.CustomerId != 26 || len(order.ShippingLines) != 1 || len(order.CouponLines) != 1 {
+		t.Errorf("unexpected order: %+v", order)
+	}
+
+	invalid := []*OrderBuilder{
+		NewOrderBuilder(products),
+		NewOrderBuilder(products).AddProduct(93, 0),
+		NewOrderBuilder(products).AddSKU("unknown", 1),
+		NewOrderBuilder(nil).AddSKU("mug", 1),
+		NewOrderBuilder(products).AddProduct(93, 1, LinePrice("-1")),
+		NewOrderBuilder(products).AddProduct(93, 1).AddShipping("", "Flat Rate", "5"),
+	}
+	for i, b := range invalid {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("builder %d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package woocommerce
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderStatus is the status of an order. Plugins may register custom statuses, which the REST
+// API returns without the "wc-" prefix of their post status, like the built-in ones.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#order-properties
+type OrderStatus string
+
+const (
+	OrderStatusPending       OrderStatus = "pending"
+	OrderStatusProcessing    OrderStatus = "processing"
+	OrderStatusOnHold        OrderStatus = "on-hold"
+	OrderStatusCompleted     OrderStatus = "completed"
+	OrderStatusCancelled     OrderStatus = "cancelled"
+	OrderStatusRefunded      OrderStatus = "refunded"
+	OrderStatusFailed        OrderStatus = "failed"
+	OrderStatusTrash         OrderStatus = "trash"
+	OrderStatusCheckoutDraft OrderStatus = "checkout-draft"
+	// OrderStatusAny matches every status in OrderListOption.Status
+	OrderStatusAny OrderStatus = "any"
+
+	orderStatusPrefix = "wc-"
+)
+
+// Normalize strips the "wc-" post status prefix, e.g. "wc-completed" is "completed"
+func (s OrderStatus) Normalize() OrderStatus {
+	return OrderStatus(strings.TrimPrefix(string(s), orderStatusPrefix))
+}
+
+// OrderStatusTransitions lists, for each status, the statuses an order may move to with
+// OrderService.Transition. WooCommerce itself accepts any change, the transitions only guard
+// against mistakes such as completing a cancelled order.
+type OrderStatusTransitions map[OrderStatus][]OrderStatus
+
+// DefaultOrderStatusTransitions returns the transitions of the built-in statuses, following
+// the order flows of WooCommerce's admin and checkout
+func DefaultOrderStatusTransitions() OrderStatusTransitions {
+	return OrderStatusTransitions{
+		OrderStatusCheckoutDraft: {OrderStatusPending, OrderStatusProcessing, OrderStatusOnHold, OrderStatusFailed, OrderStatusCancelled, OrderStatusTrash},
+		OrderStatusPending:       {OrderStatusProcessing, OrderStatusOnHold, OrderStatusCompleted, OrderStatusFailed, OrderStatusCancelled, OrderStatusTrash},
+		OrderStatusOnHold:        {OrderStatusPending, OrderStatusProcessing, OrderStatusCompleted, OrderStatusFailed, OrderStatusCancelled, OrderStatusTrash},
+		OrderStatusProcessing:    {OrderStatusOnHold, OrderStatusCompleted, OrderStatusCancelled, OrderStatusRefunded, OrderStatusTrash},
+		OrderStatusFailed:        {OrderStatusPending, OrderStatusProcessing, OrderStatusOnHold, OrderStatusCompleted, OrderStatusCancelled, OrderStatusTrash},
+		OrderStatusCompleted:     {OrderStatusProcessing, OrderStatusRefunded, OrderStatusTrash},
+		OrderStatusCancelled:     {OrderStatusPending, OrderStatusProcessing, OrderStatusOnHold, OrderStatusTrash},
+		OrderStatusRefunded:      {OrderStatusTrash},
+		OrderStatusTrash:         {},
+	}
+}
+
+// Allow adds transitions from a status
+func (t OrderStatusTransitions) Allow(from OrderStatus, to ...OrderStatus) {
+	from = from.Normalize()
+	for _, s := range to {
+		s = s.Normalize()
+		if !t.Allowed(from, s) {
+			t[from] = append(t[from], s)
+		}
+	}
+}
+
+// Allowed reports whether an order may move from a status to another. Staying in the same
+// status is always allowed.
+func (t OrderStatusTransitions) Allowed(from, to OrderStatus) bool {
+	from, to = from.Normalize(), to.Normalize()
+	if from == to {
+		return true
+	}
+	for _, s := range t[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusTransitionError is returned by OrderService.Transition for a disallowed transition
+type OrderStatusTransitionError struct {
+	OrderID int64
+	From    OrderStatus
+	To      OrderStatus
+}
+
+func (e OrderStatusTransitionError) Error() string {
+	return fmt.Sprintf("woocommerce: order %d can't move from %s to %s", e.OrderID, e.From, e.To)
+}
+
+// WithOrderStatus registers a custom order status, e.g. "shipped", with the statuses an order
+// may move to it from and the statuses it may move to from it
+func WithOrderStatus(status OrderStatus, from, to []OrderStatus) Option {
+	return func(c *Client) {
+		if c.orderStatuses == nil {
+			c.orderStatuses = OrderStatusTransitions{}
+		}
+		for _, s := range from {
+			c.orderStatuses.Allow(s, status)
+		}
+		c.orderStatuses.Allow(status, to...)
+	}
+}
+
+// WithOrderStatusTransitions replaces the allowed order status transitions, nil disables the
+// validation of OrderService.Transition
+func WithOrderStatusTransitions(transitions OrderStatusTransitions) Option {
+	return func(c *Client) {
+		c.orderStatuses = transitions
+	}
+}
+
+// Transition moves an order to a status, after checking the transition is allowed, and adds
+// note as a private order note when not empty. The updated order is returned even when adding
+// the note fails.
+func (o *OrderServiceOp) Transition(orderID int64, to OrderStatus, note string) (*Order, error) {
+	order, err := o.Get(orderID, nil)
+	if err != nil {
+		return nil, err
+	}
+	from := order.Status.Normalize()
+	to = to.Normalize()
+	if o.client.orderStatuses != nil && !o.client.orderStatuses.Allowed(from, to) {
+		return order, OrderStatusTransitionError{OrderID: orderID, From: from, To: to}
+	}
+
+	if from != to {
+		order, err = o.Patch(orderID, Patch{"status": to})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if note != "" {
+		if _, err := o.client.OrderNote.Create(orderID, note); err != nil {
+			return order, err
+		}
+	}
+	return order, nil
+}
@@ -1,9 +1,18 @@
 package woocommerce
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 const (
@@ -30,14 +39,14 @@ func TestOrderServiceOp_List(t *testing.T) {
 	options := OrderListOption{
 		ListOptions: ListOptions{
 			Context: "view",
-			After:   "2021-01-01T06:16:17",
-			Before:  "2022-01-12T06:16:17",
+			After:   NewTime(time.Date(2021, 1, 1, 6, 16, 17, 0, time.UTC)),
+			Before:  NewTime(time.Date(2022, 1, 12, 6, 16, 17, 0, time.UTC)),
 			Order:   "desc",
 			Orderby: "date",
 			Page:    2,
 			PerPage: 2,
 		},
-		Status:  []string{"processing"},
+		Status:  []OrderStatus{OrderStatusProcessing},
 		Product: 10,
 	}
 	orders, err := client.Order.List(options)
@@ -77,7 +86,7 @@ func initOrder() Order {
 					},
 				},
 				SKU:   "wutongshan_001" + timeNowStr,
-				Price: 56.00,
+				Price: "56.00",
 			},
 		},
 	}
@@ -155,3 +164,278 @@ func TestOrderServiceOp_Batch(t *testing.T) {
 		t.Logf(" order id: %v, order status : %v", order.ID, order.Status)
 	}
 }
+
+func TestOrderServiceOp_SendEmailFallback(t *testing.T) {
+	var noted bool
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wp-json/wc/v3/orders/17/actions/send_email":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"rest_no_route","message":"No route was found matching the URL and request method.","data":{"status":404}}`))
+		case "/wp-json/wc/v3/orders/17/notes":
+			var note OrderNote
+			if err := json.NewDecoder(r.Body).Decode(&note); err != nil {
+				t.Errorf("decode note fail: %v", err)
+			}
+			if !note.CustomerNote || note.Note != "invoice resent" {
+				t.Errorf("unexpected note: %+v", note)
+			}
+			noted = true
+			w.Write([]byte(`{"id":5,"note":"invoice resent","customer_note":true}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	res, err := c.Order.SendEmail(17, OrderEmailCustomerInvoice, &OrderActionOption{FallbackNote: "invoice resent"})
+	if err != nil {
+		t.Fatalf("send email fail: %v", err)
+	}
+	if !noted || res.Note == nil || res.Note.ID != 5 {
+		t.Errorf("expected fallback customer note, got %+v", res)
+	}
+
+	noted = false
+	if _, err := c.Order.SendEmail(17, OrderEmailNewOrder, nil); !isNoRouteError(err) || noted {
+		t.Errorf("admin email fell back to a customer note: %v", err)
+	}
+}
+
+func TestOrderServiceOp_ResetDownloadPermissions(t *testing.T) {
+	var body string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "PUT /wp-json/wc/v3/orders/17" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"id":17,"status":"completed","meta_data":[{"id":4,"key":"_download_permissions_granted","value":"no"}]}`))
+	}))
+
+	order, err := c.Order.ResetDownloadPermissions(17)
+	if err != nil || order.ID != 17 {
+		t.Errorf("ResetDownloadPermissions() = %+v, %v", order, err)
+	}
+	if want := `{"meta_data":[{"key":"_download_permissions_granted","value":"no"}]}`; body != want {
+		t.Errorf("body = %s\nwant   %s", body, want)
+	}
+}
+
+func TestOrder_GoldenJSON(t *testing.T) {
+	data, err := os.ReadFile("testdata/order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the models keep unknown properties in Extra, none may be left for the documented ones
+	var order Order
+	if err := json.Unmarshal(data, &order); err != nil {
+		t.Fatal(err)
+	}
+	if extra := extraProperties(reflect.ValueOf(order), "order"); len(extra) != 0 {
+		t.Errorf("order model misses fields: %v", extra)
+	}
+
+	item := order.LineItems[1]
+	if item.VariationID != 23 || item.Price != "12" || item.Image.ID != 44 || *item.ParentName != "Ship Your Idea" {
+		t.Errorf("unexpected line item: %+v", item)
+	}
+	if order.LineItems[0].Image.ID != 0 || order.LineItems[0].ParentName != nil {
+		t.Errorf("unexpected simple line item: %+v", order.LineItems[0])
+	}
+	if len(order.ShippingLines[0].Taxes) != 1 || order.ShippingLines[0].InstanceID != "3" {
+		t.Errorf("unexpected shipping line: %+v", order.ShippingLines[0])
+	}
+	if !order.NeedsProcessing || order.PaymentUrl == "" || order.DateCompleted != nil || order.DatePaid == nil {
+		t.Errorf("unexpected order: %+v", order)
+	}
+	if len(order.Links.Customer) != 1 {
+		t.Errorf("unexpected links: %+v", order.Links)
+	}
+
+	// encoding again keeps every non-empty value, numbers may turn into strings like amounts
+	encoded, err := json.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, got := goldenValue(t, data), goldenValue(t, encoded)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("round trip changed the order:\n%v\n%v", want, got)
+	}
+}
+
+// extraProperties lists the properties kept in the Extra of v and of the models nested in it
+func extraProperties(v reflect.Value, path string) []string {
+	var names []string
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			names = extraProperties(v.Elem(), path)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			names = append(names, extraProperties(v.Index(i), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if extra, ok := v.Field(i).Interface().(Extra); ok {
+				for name := range extra {
+					names = append(names, path+"."+name)
+				}
+				continue
+			}
+			names = append(names, extraProperties(v.Field(i), path+"."+jsonName(f))...)
+		}
+	}
+	return names
+}
+
+// goldenValue decodes JSON dropping empty values and turning numbers into strings
+func goldenValue(t *testing.T, data []byte) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	var prune func(v interface{}) interface{}
+	prune = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				if e = prune(e); e == nil {
+					delete(v, k)
+				} else {
+					v[k] = e
+				}
+			}
+			if len(v) == 0 {
+				return nil
+			}
+		case []interface{}:
+			list := make([]interface{}, 0, len(v))
+			for _, e := range v {
+				if e = prune(e); e != nil {
+					list = append(list, e)
+				}
+			}
+			if len(list) == 0 {
+				return nil
+			}
+			return list
+		case json.Number:
+			if f, _ := v.Float64(); f == 0 {
+				return nil
+			}
+			return v.String()
+		case string:
+			if v == "" {
+				return nil
+			}
+		case bool:
+			if !v {
+				return nil
+			}
+		}
+		return v
+	}
+	return prune(v)
+}
+
+func TestOrderServiceOp_Transition(t *testing.T) {
+	var updates, notes []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"id": 727, "status": "wc-processing"}`))
+		case r.URL.Path == "/wp-json/wc/v3/orders/727/notes":
+			notes = append(notes, string(data))
+			w.Write([]byte(`{"id": 1}`))
+		default:
+			updates = append(updates, string(data))
+			w.Write([]byte(`{"id": 727, "status": "shipped"}`))
+		}
+	}))
+	WithOrderStatus("shipped", []OrderStatus{OrderStatusProcessing}, []OrderStatus{OrderStatusCompleted})(c)
+
+	order, err := c.Order.Transition(727, "shipped", "Handed to the carrier")
+	if err != nil {
+		t.Fatalf("transition fail: %v", err)
+	}
+	if order.Status != "shipped" || len(updates) != 1 || updates[0] != `{"status":"shipped"}` || len(notes) != 1 {
+		t.Errorf("unexpected transition: %v %v %v", order.Status, updates, notes)
+	}
+
+	_, err = c.Order.Transition(727, OrderStatusPending, "")
+	var transitionErr OrderStatusTransitionError
+	if !errors.As(err, &transitionErr) || transitionErr.From != OrderStatusProcessing {
+		t.Errorf("expected transition error, got %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("disallowed transition was sent")
+	}
+}
+
+func TestOrderListOption(t *testing.T) {
+	dp := 0
+	options := OrderListOption{
+		ListOptions:   ListOptions{PerPage: 100, Search: "john"},
+		ModifiedAfter: NewTime(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		DatesAreGMT:   true,
+		DecimalPoints: &dp,
+		CreatedVia:    []string{"checkout", "admin"},
+		SearchFields:  []string{"billing_email"},
+	}
+	values, err := query.Values(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "created_via=checkout%2Cadmin&dates_are_gmt=true&dp=0&modified_after=2024-05-01T00%3A00%3A00&per_page=100&search=john&search_fields=billing_email"
+	if values.Encode() != want {
+		t.Errorf("query = %s\nwant    %s", values.Encode(), want)
+	}
+
+	values, err = query.Values(OrderListOption{
+		ListOptions: ListOptions{Include: []int64{1, 2}},
+		Parent:      []int64{3, 4},
+		Status:      []OrderStatus{OrderStatusProcessing, OrderStatusCompleted},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "include=1%2C2&parent=3%2C4&status=processing%2Ccompleted"
+	if values.Encode() != want {
+		t.Errorf("query = %s\nwant    %s", values.Encode(), want)
+	}
+
+	var dpQuery string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dpQuery = r.URL.Query().Get("dp")
+		w.Write([]byte(`[]`))
+	}))
+	if _, err := c.Order.List(&OrderListOption{Dp: 3}); err != nil || dpQuery != "3" {
+		t.Errorf("deprecated Dp sent dp=%q, %v", dpQuery, err)
+	}
+
+	invalid := []OrderListOption{
+		{ListOptions: ListOptions{PerPage: 101}},
+		{ListOptions: ListOptions{Orderby: "price"}},
+		{Status: []OrderStatus{OrderStatusAny, OrderStatusCompleted}},
+		{SearchFields: []string{"billing_email"}},
+		{ListOptions: ListOptions{Include: []int64{1, 2}, Exclude: []int64{2}}},
+		{ModifiedAfter: options.ModifiedAfter, ModifiedBefore: NewTime(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))},
+	}
+	c = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("invalid options sent %s", r.URL)
+	}))
+	for _, options := range invalid {
+		var optionErr ListOptionError
+		if _, err := c.Order.List(options); !errors.As(err, &optionErr) {
+			t.Errorf("%+v: expected option error, got %v", options, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package woocommerce
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Patch is the body of a partial update, sent as is: every key is written, including false,
+// 0 and "" values that the omitempty model structs drop, and nil values are sent as null.
+//
+//	patch := woocommerce.Patch{}.Set("featured", false).Set("stock_quantity", 0).Set("sale_price", "")
+//	product, err := client.Product.Patch(productID, patch)
+type Patch map[string]interface{}
+
+// Set sets field, a JSON property name, to value
+func (p Patch) Set(field string, value interface{}) Patch {
+	p[field] = value
+	return p
+}
+
+// SetNull sends field as null, e.g. to clear a date
+func (p Patch) SetNull(field string) Patch {
+	p[field] = nil
+	return p
+}
+
+// Fields returns the JSON property names of the patch
+func (p Patch) Fields() []string {
+	fields := make([]string, 0, len(p))
+	for field := range p {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// PatchFields returns a Patch of the listed JSON properties of v, a model struct or a pointer
+// to one, with their values even when zero. It works as a field mask over the read models:
+//
+//	product.ManageStock = woocommerce.StockNotManaged
+//	patch, err := woocommerce.PatchFields(product, "manage_stock")
+func PatchFields(v interface{}, fields ...string) (Patch, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("woocommerce: patch of nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("woocommerce: patch of non struct %T", v)
+	}
+
+	patch := make(Patch, len(fields))
+	for _, field := range fields {
+		value, ok := jsonField(rv, field)
+		if !ok {
+			return nil, fmt.Errorf("woocommerce: %s has no %q field", rv.Type(), field)
+		}
+		patch[field] = value.Interface()
+	}
+	return patch, nil
+}
+
+// jsonField returns the field of the struct value rv with the JSON name name
+func jsonField(rv reflect.Value, name string) (reflect.Value, bool) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if jsonName(f) == name {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// jsonName returns the JSON property name of a struct field, "" for ignored fields
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
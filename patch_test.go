@@ -0,0 +1,42 @@
+package woocommerce
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestProductServiceOp_Patch(t *testing.T) {
+	var body string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/wp-json/wc/v3/products/794" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"id": 794}`))
+	}))
+
+	patch := Patch{}.Set("featured", false).Set("stock_quantity", 0).Set("sale_price", "").SetNull("date_on_sale_to")
+	if _, err := c.Product.Patch(794, patch); err != nil {
+		t.Fatalf("patch fail: %v", err)
+	}
+	want := `{"date_on_sale_to":null,"featured":false,"sale_price":"","stock_quantity":0}`
+	if body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
+
+func TestPatchFields(t *testing.T) {
+	product := &Product{ID: 794, ManageStock: StockNotManaged, StockQuantity: 0, Name: "Hoodie"}
+	patch, err := PatchFields(product, "manage_stock", "stock_quantity")
+	if err != nil {
+		t.Fatalf("patch fields fail: %v", err)
+	}
+	if len(patch) != 2 || patch["manage_stock"] != StockNotManaged || patch["stock_quantity"] != 0 {
+		t.Errorf("unexpected patch: %v", patch)
+	}
+	if _, err := PatchFields(product, "no_such_field"); err == nil {
+		t.Errorf("expected unknown field error")
+	}
+}
@@ -32,6 +32,7 @@ type PaymentGateway struct {
 	MethodSupports    []string `json:"method_supports,omitempty"`
 	Settings          *Setting `json:"settings,omitempty"`
 	Links             Links    `json:"_links,omitempty"`
+	Extra             Extra    `json:"-"`
 }
 
 type Setting struct {
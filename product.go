@@ -2,8 +2,6 @@ package woocommerce
 
 import (
 	"fmt"
-	"net/http"
-	"time"
 )
 
 const (
@@ -16,9 +14,13 @@ type ProductService interface {
 	Create(product Product) (*Product, error)
 	Get(productID int64, options interface{}) (*Product, error)
 	List(options interface{}) ([]Product, error)
+	ListWithPages(options interface{}) ([]Product, *Pagination, error)
 	Update(product *Product) (*Product, error)
+	Patch(productID int64, patch Patch) (*Product, error)
+	UpdateChanged(original, changed *Product, options *UpdateChangedOption) (*Product, error)
 	Delete(productID int64, options interface{}) (*Product, error)
 	Batch(option ProductBatchOption) (*ProductBatchResource, error)
+	BatchAll(data ProductBatchOption, options *BatchOption) (*BatchResult[Product], error)
 }
 
 // ProductServiceOp handles communication with the product related methods of the WooCommerce API
@@ -28,26 +30,26 @@ type ProductServiceOp struct {
 
 type ProductListOptions struct {
 	ListOptions
-	ModifiedAfter  time.Time `json:"modified_after,omitempty" url:"modified_after,omitempty"`
-	ModifiedBefore time.Time `json:"modified_before,omitempty" url:"modified_before,omitempty"`
-	DatesAreGMT    bool      `json:"dates_are_gmt,omitempty" url:"dates_are_gmt,omitempty"`
-	Parent         []int     `json:"parent,omitempty" url:"parent,omitempty"`
-	ParentExclude  []int     `json:"parent_exclude,omitempty" url:"parent_exclude,omitempty"`
-	Slug           string    `json:"slug,omitempty" url:"slug,omitempty"`
-	Status         string    `json:"status,omitempty" url:"status,omitempty"`
-	Type           string    `json:"type,omitempty" url:"type,omitempty"`
-	SKU            string    `json:"sku,omitempty" url:"sku,omitempty"`
-	Featured       bool      `json:"featured,omitempty" url:"featured,omitempty"`
-	Category       string    `json:"category,omitempty" url:"category,omitempty"`
-	Tag            string    `json:"tag,omitempty" url:"tag,omitempty"`
-	ShippingClass  string    `json:"shipping_class,omitempty" url:"shipping_class,omitempty"`
-	Attribute      string    `json:"attribute,omitempty" url:"attribute,omitempty"`
-	AttributeTerm  string    `json:"attribute_term,omitempty" url:"attribute_term,omitempty"`
-	TaxClass       string    `json:"tax_class,omitempty" url:"tax_class,omitempty"`
-	OnSale         bool      `json:"on_sale,omitempty" url:"on_sale,omitempty"`
-	MinPrice       string    `json:"min_price,omitempty" url:"min_price,omitempty"`
-	MaxPrice       string    `json:"max_price,omitempty" url:"max_price,omitempty"`
-	StockStatus    string    `json:"stock_status,omitempty" url:"stock_status,omitempty"`
+	ModifiedAfter  Time   `json:"modified_after,omitempty" url:"modified_after,omitempty"`
+	ModifiedBefore Time   `json:"modified_before,omitempty" url:"modified_before,omitempty"`
+	DatesAreGMT    bool   `json:"dates_are_gmt,omitempty" url:"dates_are_gmt,omitempty"`
+	Parent         []int  `json:"parent,omitempty" url:"parent,omitempty"`
+	ParentExclude  []int  `json:"parent_exclude,omitempty" url:"parent_exclude,omitempty"`
+	Slug           string `json:"slug,omitempty" url:"slug,omitempty"`
+	Status         string `json:"status,omitempty" url:"status,omitempty"`
+	Type           string `json:"type,omitempty" url:"type,omitempty"`
+	SKU            string `json:"sku,omitempty" url:"sku,omitempty"`
+	Featured       bool   `json:"featured,omitempty" url:"featured,omitempty"`
+	Category       string `json:"category,omitempty" url:"category,omitempty"`
+	Tag            string `json:"tag,omitempty" url:"tag,omitempty"`
+	ShippingClass  string `json:"shipping_class,omitempty" url:"shipping_class,omitempty"`
+	Attribute      string `json:"attribute,omitempty" url:"attribute,omitempty"`
+	AttributeTerm  string `json:"attribute_term,omitempty" url:"attribute_term,omitempty"`
+	TaxClass       string `json:"tax_class,omitempty" url:"tax_class,omitempty"`
+	OnSale         bool   `json:"on_sale,omitempty" url:"on_sale,omitempty"`
+	MinPrice       string `json:"min_price,omitempty" url:"min_price,omitempty"`
+	MaxPrice       string `json:"max_price,omitempty" url:"max_price,omitempty"`
+	StockStatus    string `json:"stock_status,omitempty" url:"stock_status,omitempty"`
 }
 
 // ProductBatchOption allows for batch operations on products
@@ -68,108 +70,114 @@ type ProductBatchResource struct {
 // Product represents a WooCommerce Product
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#product-properties
 type Product struct {
-	ID                int64         `json:"id,omitempty"`
-	Name              string        `json:"name,omitempty"`
-	Slug              string        `json:"slug,omitempty"`
-	Permalink         string        `json:"permalink,omitempty"`
-	DateCreated       string        `json:"date_created,omitempty"`
-	DateCreatedGmt    string        `json:"date_created_gmt,omitempty"`
-	DateModified      string        `json:"date_modified,omitempty"`
-	DateModifiedGmt   string        `json:"date_modified_gmt,omitempty"`
-	Type              string        `json:"type,omitempty"`
-	Status            string        `json:"status,omitempty"`
-	Featured          bool          `json:"featured,omitempty"`
-	CatalogVisibility string        `json:"catalog_visibility,omitempty"`
-	Description       string        `json:"description,omitempty"`
-	ShortDescription  string        `json:"short_description,omitempty"`
-	Sku               string        `json:"sku,omitempty"`
-	Price             string        `json:"price,omitempty"`
-	RegularPrice      string        `json:"regular_price,omitempty"`
-	SalePrice         string        `json:"sale_price,omitempty"`
-	DateOnSaleFrom    string        `json:"date_on_sale_from,omitempty"`
-	DateOnSaleFromGmt string        `json:"date_on_sale_from_gmt,omitempty"`
-	DateOnSaleTo      string        `json:"date_on_sale_to,omitempty"`
-	DateOnSaleToGmt   string        `json:"date_on_sale_to_gmt,omitempty"`
-	PriceHtml         string        `json:"price_html,omitempty"`
-	OnSale            bool          `json:"on_sale,omitempty"`
-	Purchasable       bool          `json:"purchasable,omitempty"`
-	TotalSales        any           `json:"total_sales,omitempty"`
-	Virtual           bool          `json:"virtual,omitempty"`
-	Downloadable      bool          `json:"downloadable,omitempty"`
-	Downloads         []Download    `json:"downloads,omitempty"`
-	DownloadLimit     int           `json:"download_limit,omitempty"`
-	DownloadExpiry    int           `json:"download_expiry,omitempty"`
-	ExternalUrl       string        `json:"external_url,omitempty"`
-	ButtonText        string        `json:"button_text,omitempty"`
-	TaxStatus         string        `json:"tax_status,omitempty"`
-	TaxClass          string        `json:"tax_class,omitempty"`
-	ManageStock       any           `json:"manage_stock,omitempty"`
-	StockQuantity     int           `json:"stock_quantity,omitempty"`
-	StockStatus       string        `json:"stock_status,omitempty"`
-	Backorders        string        `json:"backorders,omitempty"`
-	BackordersAllowed bool          `json:"backorders_allowed,omitempty"`
-	Backordered       bool          `json:"backordered,omitempty"`
-	SoldIndividually  bool          `json:"sold_individually,omitempty"`
-	Weight            string        `json:"weight,omitempty"`
-	Dimensions        *Dimensions   `json:"dimensions,omitempty"`
-	ShippingRequired  bool          `json:"shipping_required,omitempty"`
-	ShippingTaxable   bool          `json:"shipping_taxable,omitempty"`
-	ShippingClass     string        `json:"shipping_class,omitempty"`
-	ShippingClassId   int64         `json:"shipping_class_id,omitempty"`
-	ReviewsAllowed    bool          `json:"reviews_allowed,omitempty"`
-	AverageRating     string        `json:"average_rating,omitempty"`
-	RatingCount       int           `json:"rating_count,omitempty"`
-	RelatedIds        []int64       `json:"related_ids,omitempty"`
-	UpsellIds         []int64       `json:"upsell_ids,omitempty"`
-	CrossSellIds      []int64       `json:"cross_sell_ids,omitempty"`
-	ParentId          int64         `json:"parent_id,omitempty"`
-	PurchaseNote      string        `json:"purchase_note,omitempty"`
-	Categories        []Category    `json:"categories,omitempty"`
-	Tags              []Tag         `json:"tags,omitempty"`
-	Images            []Image       `json:"images,omitempty"`
-	Attributes        []Attribute   `json:"attributes,omitempty"`
-	DefaultAttributes []DefaultAttr `json:"default_attributes,omitempty"`
-	Variations        []int64       `json:"variations,omitempty"`
-	GroupedProducts   []int64       `json:"grouped_products,omitempty"`
-	MenuOrder         int           `json:"menu_order,omitempty"`
-	MetaData          []MetaData    `json:"meta_data,omitempty"`
-	Links             Links         `json:"_links,omitempty"`
+	ID                int64           `json:"id,omitempty"`
+	Name              string          `json:"name,omitempty"`
+	Slug              string          `json:"slug,omitempty"`
+	Permalink         string          `json:"permalink,omitempty"`
+	DateCreated       *Time           `json:"date_created,omitempty"`
+	DateCreatedGmt    *Time           `json:"date_created_gmt,omitempty"`
+	DateModified      *Time           `json:"date_modified,omitempty"`
+	DateModifiedGmt   *Time           `json:"date_modified_gmt,omitempty"`
+	Type              string          `json:"type,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	Featured          bool            `json:"featured,omitempty"`
+	CatalogVisibility string          `json:"catalog_visibility,omitempty"`
+	Description       string          `json:"description,omitempty"`
+	ShortDescription  string          `json:"short_description,omitempty"`
+	Sku               string          `json:"sku,omitempty"`
+	Price             Amount          `json:"price,omitempty"`
+	RegularPrice      Amount          `json:"regular_price,omitempty"`
+	SalePrice         Amount          `json:"sale_price,omitempty"`
+	DateOnSaleFrom    *Time           `json:"date_on_sale_from,omitempty"`
+	DateOnSaleFromGmt *Time           `json:"date_on_sale_from_gmt,omitempty"`
+	DateOnSaleTo      *Time           `json:"date_on_sale_to,omitempty"`
+	DateOnSaleToGmt   *Time           `json:"date_on_sale_to_gmt,omitempty"`
+	PriceHtml         string          `json:"price_html,omitempty"`
+	OnSale            bool            `json:"on_sale,omitempty"`
+	Purchasable       bool            `json:"purchasable,omitempty"`
+	TotalSales        Count           `json:"total_sales,omitempty"`
+	Virtual           bool            `json:"virtual,omitempty"`
+	Downloadable      bool            `json:"downloadable,omitempty"`
+	Downloads         []Download      `json:"downloads,omitempty"`
+	DownloadLimit     int             `json:"download_limit,omitempty"`
+	DownloadExpiry    int             `json:"download_expiry,omitempty"`
+	ExternalUrl       string          `json:"external_url,omitempty"`
+	ButtonText        string          `json:"button_text,omitempty"`
+	TaxStatus         string          `json:"tax_status,omitempty"`
+	TaxClass          string          `json:"tax_class,omitempty"`
+	ManageStock       StockManagement `json:"manage_stock,omitempty"`
+	StockQuantity     int             `json:"stock_quantity,omitempty"`
+	StockStatus       string          `json:"stock_status,omitempty"`
+	Backorders        string          `json:"backorders,omitempty"`
+	BackordersAllowed bool            `json:"backorders_allowed,omitempty"`
+	Backordered       bool            `json:"backordered,omitempty"`
+	SoldIndividually  bool            `json:"sold_individually,omitempty"`
+	Weight            string          `json:"weight,omitempty"`
+	Dimensions        *Dimensions     `json:"dimensions,omitempty"`
+	ShippingRequired  bool            `json:"shipping_required,omitempty"`
+	ShippingTaxable   bool            `json:"shipping_taxable,omitempty"`
+	ShippingClass     string          `json:"shipping_class,omitempty"`
+	ShippingClassId   int64           `json:"shipping_class_id,omitempty"`
+	ReviewsAllowed    bool            `json:"reviews_allowed,omitempty"`
+	AverageRating     string          `json:"average_rating,omitempty"`
+	RatingCount       int             `json:"rating_count,omitempty"`
+	RelatedIds        []int64         `json:"related_ids,omitempty"`
+	UpsellIds         []int64         `json:"upsell_ids,omitempty"`
+	CrossSellIds      []int64         `json:"cross_sell_ids,omitempty"`
+	ParentId          int64           `json:"parent_id,omitempty"`
+	PurchaseNote      string          `json:"purchase_note,omitempty"`
+	Categories        []Category      `json:"categories,omitempty"`
+	Tags              []Tag           `json:"tags,omitempty"`
+	Images            []Image         `json:"images,omitempty"`
+	Attributes        []Attribute     `json:"attributes,omitempty"`
+	DefaultAttributes []DefaultAttr   `json:"default_attributes,omitempty"`
+	Variations        []int64         `json:"variations,omitempty"`
+	GroupedProducts   []int64         `json:"grouped_products,omitempty"`
+	MenuOrder         int             `json:"menu_order,omitempty"`
+	MetaData          MetaDataList    `json:"meta_data,omitempty"`
+	Links             Links           `json:"_links,omitempty"`
+	Extra             Extra           `json:"-"`
 }
 
 type Dimensions struct {
 	Length string `json:"length,omitempty"`
 	Width  string `json:"width,omitempty"`
 	Height string `json:"height,omitempty"`
+	Extra  Extra  `json:"-"`
 }
 
 type Download struct {
-	Id   string `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
-	File string `json:"file,omitempty"`
+	Id    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	File  string `json:"file,omitempty"`
+	Extra Extra  `json:"-"`
 }
 
 type Category struct {
-	Id   int64  `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
-	Slug string `json:"slug,omitempty"`
+	Id    int64  `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Slug  string `json:"slug,omitempty"`
+	Extra Extra  `json:"-"`
 }
 
 type Tag struct {
-	Id   int64  `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
-	Slug string `json:"slug,omitempty"`
+	Id    int64  `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Slug  string `json:"slug,omitempty"`
+	Extra Extra  `json:"-"`
 }
 
 type Image struct {
 	Id              int64  `json:"id,omitempty"`
-	DateCreated     string `json:"date_created,omitempty"`
-	DateCreatedGmt  string `json:"date_created_gmt,omitempty"`
-	DateModified    string `json:"date_modified,omitempty"`
-	DateModifiedGmt string `json:"date_modified_gmt,omitempty"`
+	DateCreated     *Time  `json:"date_created,omitempty"`
+	DateCreatedGmt  *Time  `json:"date_created_gmt,omitempty"`
+	DateModified    *Time  `json:"date_modified,omitempty"`
+	DateModifiedGmt *Time  `json:"date_modified_gmt,omitempty"`
 	Src             string `json:"src,omitempty"`
 	Name            string `json:"name,omitempty"`
 	Alt             string `json:"alt,omitempty"`
 	Position        int    `json:"position,omitempty"`
+	Extra           Extra  `json:"-"`
 }
 
 type Attribute struct {
@@ -179,68 +187,87 @@ type Attribute struct {
 	Visible   bool     `json:"visible,omitempty"`
 	Variation bool     `json:"variation,omitempty"`
 	Options   []string `json:"options,omitempty"`
+	Extra     Extra    `json:"-"`
 }
 
 type DefaultAttr struct {
 	Id     int64  `json:"id,omitempty"`
 	Name   string `json:"name,omitempty"`
 	Option string `json:"option,omitempty"`
+	Extra  Extra  `json:"-"`
 }
 
 func (p *ProductServiceOp) List(options interface{}) ([]Product, error) {
-	products, err := p.ListWithPagination(options)
+	products, _, err := p.ListWithPages(options)
 	return products, err
 }
 
-// ListWithPagination lists products and returns pagination to retrieve next/previous results.
+// ListWithPagination lists products
+//
+// Deprecated: ListWithPagination doesn't return the pagination, use ListWithPages.
 func (p *ProductServiceOp) ListWithPagination(options interface{}) ([]Product, error) {
-	path := fmt.Sprintf("%s", productsBasePath)
-	resource := make([]Product, 0)
-	headers := http.Header{}
-	headers, err := p.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
-	if err != nil {
-		return nil, err
-	}
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-	fmt.Println(linkHeader)
-	// pagination, err := extractPagination(linkHeader)
-	// if err != nil {
-	// 	return nil, nil, err
-	// }
+	return p.List(options)
+}
 
-	return resource, err
+// ListWithPages lists products and returns pagination to retrieve next/previous results.
+func (p *ProductServiceOp) ListWithPages(options interface{}) ([]Product, *Pagination, error) {
+	return p.resource().List(options)
 }
 
+// Create a product after checking the properties required by its type, see Product.Validate
 func (p *ProductServiceOp) Create(product Product) (*Product, error) {
-	path := fmt.Sprintf("%s", productsBasePath)
-	resource := new(Product)
-	err := p.client.Post(path, product, &resource)
-	return resource, err
+	if err := product.Validate(); err != nil {
+		return nil, err
+	}
+	return p.resource().Create(product)
 }
 
 // Get individual product
 func (p *ProductServiceOp) Get(productID int64, options interface{}) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, productID)
-	resource := new(Product)
-	err := p.client.Get(path, resource, options)
-	return resource, err
+	return p.resource().Get(productID, options)
 }
 
 // Update existing product
 func (p *ProductServiceOp) Update(product *Product) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, product.ID)
-	resource := new(Product)
-	err := p.client.Put(path, product, &resource)
-	return resource, err
+	return p.resource().Update(product.ID, product)
+}
+
+// Patch updates the listed properties of a product, see Patch
+func (p *ProductServiceOp) Patch(productID int64, patch Patch) (*Product, error) {
+	return p.resource().Patch(productID, patch)
+}
+
+// UpdateChanged updates a product with the properties of changed that differ from original,
+// the product as fetched, see Diff. Make changed with original.Clone(). Nothing is sent when they
+// are equal and the error is ErrNoChanges.
+func (p *ProductServiceOp) UpdateChanged(original, changed *Product, options *UpdateChangedOption) (*Product, error) {
+	patch, err := diffForUpdate(original.ID, changed.ID, original, changed)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return changed, ErrNoChanges
+	}
+	if options != nil && options.CheckModified {
+		current, err := p.Get(original.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkModified(original.DateModifiedGmt, current.DateModifiedGmt); err != nil {
+			return current, err
+		}
+	}
+	return p.Patch(original.ID, patch)
+}
+
+// Clone returns a deep copy of the product, to change and pass to UpdateChanged with the original
+func (p *Product) Clone() *Product {
+	return cloneOf(p)
 }
 
 // Delete existing product
 func (p *ProductServiceOp) Delete(productID int64, options interface{}) (*Product, error) {
-	path := fmt.Sprintf("%s/%d", productsBasePath, productID)
-	resource := new(Product)
-	err := p.client.Delete(path, options, &resource)
-	return resource, err
+	return p.resource().Delete(productID, options)
 }
 
 // Batch implements ProductService.
@@ -250,3 +277,12 @@ func (p *ProductServiceOp) Batch(data ProductBatchOption) (*ProductBatchResource
 	err := p.client.Post(path, data, &resource)
 	return resource, err
 }
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each product
+func (p *ProductServiceOp) BatchAll(data ProductBatchOption, options *BatchOption) (*BatchResult[Product], error) {
+	return p.resource().Batch(BatchRequest[Product](data), options)
+}
+
+func (p *ProductServiceOp) resource() *Resource[Product] {
+	return NewResource[Product](p.client, productsBasePath)
+}
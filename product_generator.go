@@ -0,0 +1,414 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// VariationAxis is an attribute of a variable product and the options its variations take. ID
+// is the ID of a global attribute, 0 for a custom one identified by Name.
+type VariationAxis struct {
+	ID      int64
+	Name    string
+	Options []string
+	Visible bool
+}
+
+// VariationOverride customizes the variation of a combination of options, e.g. its price, SKU
+// or stock. options maps each axis name to the combination's option.
+type VariationOverride func(options map[string]string, variation *ProductVariation)
+
+// VariableProductSpec describes a variable product with a variation for every combination of
+// the options of its axes:
+//
+//	spec := woocommerce.VariableProductSpec{
+//		Product: woocommerce.Product{Name: "Hoodie", Sku: "hoodie"},
+//		Axes: []woocommerce.VariationAxis{
+//			{Name: "Size", Options: []string{"S", "M", "L"}},
+//			{Name: "Color", Options: []string{"Blue", "Red"}},
+//		},
+//		Variation: woocommerce.ProductVariation{RegularPrice: "45.00", ManageStock: woocommerce.StockManaged},
+//		Overrides: []woocommerce.VariationOverride{
+//			func(options map[string]string, v *woocommerce.ProductVariation) {
+//				v.Sku = "hoodie-" + strings.ToLower(options["Color"]+"-"+options["Size"])
+//			},
+//		},
+//	}
+//
+// Product is created when its ID is 0. Variation holds the properties shared by every
+// variation, Overrides are applied to each in order.
+type VariableProductSpec struct {
+	Product   Product
+	Axes      []VariationAxis
+	Variation ProductVariation
+	Overrides []VariationOverride
+}
+
+// VariableProductOption configures GenerateVariableProduct. UpdateExisting applies the spec to
+// the variations that already exist, otherwise only missing combinations are created. Prune
+// deletes the variations and attribute options not in the spec, except the variations matching
+// any option of an axis, e.g. any size, which PruneAny deletes too. DryRun only computes the plan.
+type VariableProductOption struct {
+	UpdateExisting bool
+	Prune          bool
+	PruneAny       bool
+	DryRun         bool
+}
+
+// VariableProductPlan lists the operations converging a variable product to its spec, Product
+// is the parent product, created or updated by GenerateVariableProduct
+type VariableProductPlan struct {
+	Product *Product
+	Create  []ProductVariation
+	Update  []ProductVariation
+	Delete  []ProductVariation
+}
+
+// Empty reports whether the variations already match the spec
+func (p *VariableProductPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// GenerateVariableProduct creates or updates the parent product of the spec with its axes as
+// variation attributes and the properties set on spec.Product, then creates the variations of the missing combinations through
+// ProductVariationService.BatchAll. Running it again after adding an option only creates the new
+// combinations.
+func GenerateVariableProduct(products ProductService, variations ProductVariationService, spec VariableProductSpec, options VariableProductOption) (*VariableProductPlan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	parent := spec.Product
+	patch := Patch{}
+	current := make([]ProductVariation, 0)
+	if parent.ID != 0 {
+		existing, err := products.Get(parent.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if patch, err = productChanges(*existing, spec.Product); err != nil {
+			return nil, err
+		}
+		parent = *existing
+		if err := applyProductChanges(&parent, patch); err != nil {
+			return nil, err
+		}
+		if current, err = ListAllVariations(variations, parent.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	attributes, changed := mergeVariationAxes(parent.Attributes, spec.Axes, options.Prune)
+	plan, err := PlanVariations(current, spec, options)
+	if err != nil {
+		return nil, err
+	}
+	parent.Attributes = attributes
+	plan.Product = &parent
+	if options.DryRun {
+		return plan, nil
+	}
+
+	if changed {
+		patch["attributes"] = attributes
+	}
+	switch {
+	case parent.ID == 0:
+		parent.Type = ProductTypeVariable
+		created, err := products.Create(parent)
+		if err != nil {
+			return plan, err
+		}
+		plan.Product = created
+	case len(patch) > 0:
+		updated, err := products.Patch(parent.ID, patch)
+		if err != nil {
+			return plan, err
+		}
+		plan.Product = updated
+	}
+
+	data := ProductVariationBatchOption{Create: plan.Create, Update: plan.Update}
+	for _, v := range plan.Delete {
+		data.Delete = append(data.Delete, v.ID)
+	}
+	result, err := variations.BatchAll(plan.Product.ID, data, nil)
+	if err != nil {
+		return plan, err
+	}
+	return plan, result.Err()
+}
+
+// PlanVariations diffs the current variations of a product against the spec. Variations are
+// matched by the options of the spec's axes, extra variations with the same options are
+// duplicates deleted with Prune. Variations matching any option of an axis are only deleted
+// with PruneAny.
+func PlanVariations(current []ProductVariation, spec VariableProductSpec, options VariableProductOption) (*VariableProductPlan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]ProductVariation, len(current))
+	plan := new(VariableProductPlan)
+	for _, v := range current {
+		key := spec.variationKey(v)
+		if key == "" {
+			if options.PruneAny {
+				plan.Delete = append(plan.Delete, v)
+			}
+			continue
+		}
+		if _, ok := existing[key]; ok {
+			if options.Prune {
+				plan.Delete = append(plan.Delete, v)
+			}
+			continue
+		}
+		existing[key] = v
+	}
+
+	for _, combination := range spec.combinations() {
+		want, err := spec.variation(combination)
+		if err != nil {
+			return nil, err
+		}
+		key := spec.variationKey(want)
+		have, ok := existing[key]
+		delete(existing, key)
+		if !ok {
+			plan.Create = append(plan.Create, want)
+			continue
+		}
+		if options.UpdateExisting {
+			want.ID = have.ID
+			if changed, err := variationChanged(have, want); err != nil {
+				return nil, err
+			} else if changed {
+				plan.Update = append(plan.Update, want)
+			}
+		}
+	}
+
+	if options.Prune {
+		for _, v := range current {
+			if unmatched, ok := existing[spec.variationKey(v)]; ok && unmatched.ID == v.ID {
+				plan.Delete = append(plan.Delete, v)
+			}
+		}
+	}
+	return plan, nil
+}
+
+// ListAllVariations lists the variations of a product, following the pages
+func ListAllVariations(svc ProductVariationService, productID int64) ([]ProductVariation, error) {
+	variations := make([]ProductVariation, 0)
+	for page := 1; ; page++ {
+		options := ProductVariationListOptions{ListOptions: ListOptions{Page: page, PerPage: maxPerPage}}
+		list, _, err := svc.List(productID, options)
+		if err != nil {
+			return nil, err
+		}
+		variations = append(variations, list...)
+		if len(list) < maxPerPage {
+			return variations, nil
+		}
+	}
+}
+
+func (s *VariableProductSpec) validate() error {
+	if len(s.Axes) == 0 {
+		return errors.New("woocommerce: variable product spec without axes")
+	}
+	names := make(map[string]bool, len(s.Axes))
+	for _, axis := range s.Axes {
+		name := strings.ToLower(axis.Name)
+		if name == "" || len(axis.Options) == 0 {
+			return errors.New("woocommerce: variation axis needs a name and options")
+		}
+		if names[name] {
+			return fmt.Errorf("woocommerce: duplicate variation axis %s", axis.Name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// combinations returns every combination of the axes' options, the last axis varying fastest
+func (s *VariableProductSpec) combinations() [][]VariationAttribute {
+	combinations := [][]VariationAttribute{{}}
+	for _, axis := range s.Axes {
+		next := make([][]VariationAttribute, 0, len(combinations)*len(axis.Options))
+		for _, combination := range combinations {
+			for _, option := range axis.Options {
+				attributes := append(append([]VariationAttribute{}, combination...), VariationAttribute{ID: axis.ID, Name: axis.Name, Option: option})
+				next = append(next, attributes)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// variation returns the variation of a combination, with the spec's shared properties and
+// overrides applied
+func (s *VariableProductSpec) variation(combination []VariationAttribute) (ProductVariation, error) {
+	var v ProductVariation
+	// deep copy so overrides can't alter the shared properties
+	data, err := json.Marshal(s.Variation)
+	if err != nil {
+		return v, err
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, err
+	}
+	v.ID = 0
+	v.Attributes = combination
+
+	options := make(map[string]string, len(combination))
+	for _, attribute := range combination {
+		options[attribute.Name] = attribute.Option
+	}
+	for _, override := range s.Overrides {
+		override(options, &v)
+	}
+	v.Attributes = combination
+	return v, nil
+}
+
+// variationKey identifies a variation by its options of the spec's axes, "" when it lacks one
+// of them, e.g. a variation matching any size
+func (s *VariableProductSpec) variationKey(v ProductVariation) string {
+	options := make([]string, 0, len(s.Axes))
+	for _, axis := range s.Axes {
+		option := ""
+		for _, attribute := range v.Attributes {
+			if axis.matches(attribute.ID, attribute.Name) {
+				option = attribute.Option
+				break
+			}
+		}
+		if option == "" {
+			return ""
+		}
+		options = append(options, strings.ToLower(option))
+	}
+	return strings.Join(options, "\x00")
+}
+
+func (a VariationAxis) matches(id int64, name string) bool {
+	if a.ID != 0 && id != 0 {
+		return a.ID == id
+	}
+	return strings.EqualFold(a.Name, name)
+}
+
+// mergeVariationAxes returns the product attributes with the axes' options, added to the
+// existing ones or replacing them with prune, and whether they changed
+func mergeVariationAxes(attributes []Attribute, axes []VariationAxis, prune bool) ([]Attribute, bool) {
+	merged := append([]Attribute{}, attributes...)
+	changed := false
+	for _, axis := range axes {
+		i := -1
+		for j, attribute := range merged {
+			if axis.matches(attribute.Id, attribute.Name) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			merged = append(merged, Attribute{
+				Id:        axis.ID,
+				Name:      axis.Name,
+				Position:  len(merged),
+				Visible:   axis.Visible,
+				Variation: true,
+				Options:   axis.Options,
+			})
+			changed = true
+			continue
+		}
+
+		attribute := merged[i]
+		options := axis.Options
+		if !prune {
+			options = unionOptions(attribute.Options, axis.Options)
+		}
+		if !attribute.Variation || !reflect.DeepEqual(attribute.Options, options) {
+			attribute.Variation = true
+			attribute.Options = options
+			merged[i] = attribute
+			changed = true
+		}
+	}
+	return merged, changed
+}
+
+// unionOptions appends the options missing from current, compared case insensitively
+func unionOptions(current, options []string) []string {
+	union := append([]string{}, current...)
+	for _, option := range options {
+		found := false
+		for _, c := range current {
+			if strings.EqualFold(c, option) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			union = append(union, option)
+		}
+	}
+	return union
+}
+
+// productChanges returns the properties set on want, other than its ID and attributes, that
+// differ from have
+func productChanges(have, want Product) (Patch, error) {
+	wanted, err := toPatch(want)
+	if err != nil {
+		return nil, err
+	}
+	current, err := toPatch(have)
+	if err != nil {
+		return nil, err
+	}
+	changes := Patch{}
+	for name, value := range wanted {
+		if name != "id" && name != "attributes" && !reflect.DeepEqual(current[name], value) {
+			changes[name] = value
+		}
+	}
+	return changes, nil
+}
+
+// applyProductChanges sets the properties of changes on p, keeping its Extra
+func applyProductChanges(p *Product, changes Patch) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	type product Product
+	return json.Unmarshal(data, (*product)(p))
+}
+
+// variationChanged reports whether a property set on want differs from have
+func variationChanged(have, want ProductVariation) (bool, error) {
+	wanted, err := toPatch(want)
+	if err != nil {
+		return false, err
+	}
+	current, err := toPatch(have)
+	if err != nil {
+		return false, err
+	}
+	delete(wanted, "attributes")
+	for name, value := range wanted {
+		if !reflect.DeepEqual(current[name], value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
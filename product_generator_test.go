@@ -0,0 +1,148 @@
+package woocommerce
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeVariationService stores the variations of batches in memory
+type fakeVariationService struct {
+	ProductVariationService
+	variations []ProductVariation
+	batches    []ProductVariationBatchOption
+}
+
+func (f *fakeVariationService) List(productID int64, options interface{}) ([]ProductVariation, *Pagination, error) {
+	return f.variations, new(Pagination), nil
+}
+
+func (f *fakeVariationService) BatchAll(productID int64, data ProductVariationBatchOption, options *BatchOption) (*BatchResult[ProductVariation], error) {
+	f.batches = append(f.batches, data)
+	result := new(BatchResult[ProductVariation])
+	for _, v := range data.Create {
+		v.ID = int64(1000 + len(f.variations))
+		v.ParentID = productID
+		f.variations = append(f.variations, v)
+		result.Create = append(result.Create, BatchItem[ProductVariation]{ID: v.ID, Resource: &v})
+	}
+	return result, nil
+}
+
+func TestGenerateVariableProduct(t *testing.T) {
+	products := &fakeProductService{}
+	variations := &fakeVariationService{}
+	spec := VariableProductSpec{
+		Product: Product{Name: "Hoodie"},
+		Axes: []VariationAxis{
+			{Name: "Size", Options: []string{"S", "M"}},
+			{ID: 6, Name: "Color", Options: []string{"Blue", "Red"}},
+		},
+		Variation: ProductVariation{RegularPrice: "45.00", ManageStock: StockManaged},
+		Overrides: []VariationOverride{
+			func(options map[string]string, v *ProductVariation) {
+				v.Sku = strings.ToLower("hoodie-" + options["Color"] + "-" + options["Size"])
+				if options["Size"] == "M" {
+					v.RegularPrice = "49.00"
+				}
+			},
+		},
+	}
+
+	plan, err := GenerateVariableProduct(products, variations, spec, VariableProductOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Product.ID == 0 || plan.Product.Type != ProductTypeVariable || len(plan.Product.VariationAttributes()) != 2 {
+		t.Fatalf("parent = %+v", plan.Product)
+	}
+	if len(plan.Create) != 4 || len(variations.variations) != 4 {
+		t.Fatalf("created %d variations, want 4", len(variations.variations))
+	}
+	first := variations.variations[0]
+	if first.Sku != "hoodie-blue-s" || first.RegularPrice != "45.00" || len(first.Attributes) != 2 {
+		t.Errorf("first variation = %+v", first)
+	}
+	if variations.variations[2].RegularPrice != "49.00" {
+		t.Errorf("override price = %s", variations.variations[2].RegularPrice)
+	}
+
+	// adding a color only creates its variations, the product properties of the spec are updated
+	spec.Product = Product{ID: plan.Product.ID, ShortDescription: "Warm hoodie"}
+	spec.Axes[1].Options = append(spec.Axes[1].Options, "Green")
+	plan, err = GenerateVariableProduct(products, variations, spec, VariableProductOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Create) != 2 || len(plan.Update) != 0 || len(plan.Delete) != 0 {
+		t.Fatalf("plan = %d create, %d update, %d delete", len(plan.Create), len(plan.Update), len(plan.Delete))
+	}
+	for _, v := range plan.Create {
+		if color, _ := v.Option("Color"); color != "Green" {
+			t.Errorf("created %s variation", color)
+		}
+	}
+	if colors := plan.Product.Attributes[1].Options; len(colors) != 3 {
+		t.Errorf("parent colors = %v", colors)
+	}
+	if len(products.patches) != 1 || products.patches[0]["short_description"] != "Warm hoodie" || products.patches[0]["name"] != nil {
+		t.Errorf("patches = %v", products.patches)
+	}
+	if plan.Product.Name != "Hoodie" || plan.Product.ShortDescription != "Warm hoodie" {
+		t.Errorf("parent = %+v", plan.Product)
+	}
+	if len(variations.batches) != 2 {
+		t.Errorf("%d batches, want 2", len(variations.batches))
+	}
+}
+
+func TestPlanVariations(t *testing.T) {
+	spec := VariableProductSpec{
+		Axes:      []VariationAxis{{Name: "Size", Options: []string{"S", "M"}}},
+		Variation: ProductVariation{RegularPrice: "10.00"},
+	}
+	current := []ProductVariation{
+		{ID: 1, RegularPrice: "10.00", Attributes: []VariationAttribute{{Name: "size", Option: "s"}}},
+		{ID: 2, RegularPrice: "10.00", Attributes: []VariationAttribute{{Name: "Size", Option: "S"}}},
+		{ID: 3, RegularPrice: "12.00", Attributes: []VariationAttribute{{Name: "Size", Option: "M"}}},
+		{ID: 4, RegularPrice: "10.00", Attributes: []VariationAttribute{{Name: "Size", Option: "XL"}}},
+		{ID: 5, RegularPrice: "10.00"},
+	}
+
+	plan, err := PlanVariations(current, spec, VariableProductOption{UpdateExisting: true, Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Delete) != 2 || plan.Delete[0].ID != 2 || plan.Delete[1].ID != 4 {
+		t.Errorf("delete = %+v, want 2 and 4, the any size variation kept", plan.Delete)
+	}
+
+	plan, err = PlanVariations(current, spec, VariableProductOption{UpdateExisting: true, Prune: true, PruneAny: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Create) != 0 {
+		t.Errorf("create = %+v", plan.Create)
+	}
+	if len(plan.Update) != 1 || plan.Update[0].ID != 3 || plan.Update[0].RegularPrice != "10.00" {
+		t.Errorf("update = %+v", plan.Update)
+	}
+	deleted := make([]int64, 0)
+	for _, v := range plan.Delete {
+		deleted = append(deleted, v.ID)
+	}
+	if len(deleted) != 3 || deleted[0] != 2 || deleted[1] != 5 || deleted[2] != 4 {
+		t.Errorf("delete = %v, want [2 5 4]", deleted)
+	}
+
+	plan, err = PlanVariations(current, spec, VariableProductOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan without options = %+v", plan)
+	}
+
+	if _, err := PlanVariations(nil, VariableProductSpec{}, VariableProductOption{}); err == nil {
+		t.Error("PlanVariations() without axes succeeded")
+	}
+}
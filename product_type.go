@@ -0,0 +1,226 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Product types, see Product.Type
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#product-properties
+const (
+	ProductTypeSimple    = "simple"
+	ProductTypeVariable  = "variable"
+	ProductTypeGrouped   = "grouped"
+	ProductTypeExternal  = "external"
+	ProductTypeVariation = "variation"
+)
+
+// StockManagement is the manage_stock property, a boolean on products and true, false or
+// "parent" on variations whose stock is managed by their parent product. The zero value
+// leaves the property unset.
+type StockManagement string
+
+const (
+	StockManaged         StockManagement = "true"
+	StockNotManaged      StockManagement = "false"
+	StockManagedByParent StockManagement = "parent"
+)
+
+// Managed reports whether the stock is tracked, by the product itself or its parent
+func (s StockManagement) Managed() bool {
+	return s == StockManaged || s == StockManagedByParent
+}
+
+// MarshalJSON encodes the booleans as JSON booleans and "parent" as a string
+func (s StockManagement) MarshalJSON() ([]byte, error) {
+	switch s {
+	case "":
+		return []byte("null"), nil
+	case StockManaged, StockNotManaged:
+		return []byte(s), nil
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON accepts booleans, "parent" and null
+func (s *StockManagement) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "null":
+		*s = ""
+	case "true":
+		*s = StockManaged
+	case "false":
+		*s = StockNotManaged
+	case `"parent"`:
+		*s = StockManagedByParent
+	default:
+		return fmt.Errorf("woocommerce: invalid manage_stock %s", data)
+	}
+	return nil
+}
+
+// Count is a non-negative count that WooCommerce sends as a number or a numeric string,
+// e.g. total_sales
+type Count int64
+
+// UnmarshalJSON accepts non negative integers, as numbers or strings, and null
+func (c *Count) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	if s == "" || s == "null" {
+		*c = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("woocommerce: invalid count %s", data)
+	}
+	*c = Count(n)
+	return nil
+}
+
+// NewSimpleProduct returns a simple product to create
+func NewSimpleProduct(name string, regularPrice Amount) Product {
+	return Product{Type: ProductTypeSimple, Name: name, RegularPrice: regularPrice}
+}
+
+// NewVariableProduct returns a variable product to create, at least one attribute must be
+// used for variations. Create the variations with ProductVariationService.
+func NewVariableProduct(name string, attributes ...Attribute) Product {
+	return Product{Type: ProductTypeVariable, Name: name, Attributes: attributes}
+}
+
+// NewGroupedProduct returns a grouped product of the children product IDs to create
+func NewGroupedProduct(name string, children ...int64) Product {
+	return Product{Type: ProductTypeGrouped, Name: name, GroupedProducts: children}
+}
+
+// NewExternalProduct returns an external/affiliate product to create, buying it sends the
+// customer to productURL
+func NewExternalProduct(name, productURL, buttonText string, regularPrice Amount) Product {
+	return Product{
+		Type:         ProductTypeExternal,
+		Name:         name,
+		ExternalUrl:  productURL,
+		ButtonText:   buttonText,
+		RegularPrice: regularPrice,
+	}
+}
+
+// Validate checks the properties required by the product's type, types added by plugins are
+// only checked for valid prices. The name isn't required, WooCommerce names products created
+// without one "Product".
+func (p *Product) Validate() error {
+	for name, amount := range map[string]Amount{"regular_price": p.RegularPrice, "sale_price": p.SalePrice} {
+		if _, err := ParseMoney(string(amount), ""); err != nil {
+			return fmt.Errorf("woocommerce: product %s: %w", name, err)
+		}
+	}
+
+	switch p.Type {
+	case ProductTypeVariable:
+		if p.RegularPrice != "" || p.SalePrice != "" {
+			return errors.New("woocommerce: variable product prices are set on its variations")
+		}
+		if len(p.VariationAttributes()) == 0 {
+			return errors.New("woocommerce: variable product without variation attribute")
+		}
+		for _, attribute := range p.VariationAttributes() {
+			if len(attribute.Options) == 0 {
+				return fmt.Errorf("woocommerce: variation attribute %s without options", attribute.Name)
+			}
+		}
+	case ProductTypeGrouped:
+		if p.RegularPrice != "" || p.SalePrice != "" {
+			return errors.New("woocommerce: grouped product prices are those of its children")
+		}
+		if len(p.GroupedProducts) == 0 {
+			return errors.New("woocommerce: grouped product without children")
+		}
+	case ProductTypeExternal:
+		u, err := url.Parse(p.ExternalUrl)
+		if p.ExternalUrl == "" || err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("woocommerce: external product needs an absolute external_url, got %q", p.ExternalUrl)
+		}
+		if p.ManageStock.Managed() {
+			return errors.New("woocommerce: external product stock can't be managed")
+		}
+	case ProductTypeVariation:
+		return errors.New("woocommerce: create variations with ProductVariationService")
+	}
+	return nil
+}
+
+// VariationAttributes returns the attributes used for variations
+func (p *Product) VariationAttributes() []Attribute {
+	attributes := make([]Attribute, 0)
+	for _, attribute := range p.Attributes {
+		if attribute.Variation {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// VariableProduct is the view of a variable product
+type VariableProduct struct {
+	*Product
+}
+
+// GroupedProduct is the view of a grouped product
+type GroupedProduct struct {
+	*Product
+}
+
+// ExternalProduct is the view of an external/affiliate product
+type ExternalProduct struct {
+	*Product
+}
+
+// AsVariable returns the variable product view, ok is false for other types
+func (p *Product) AsVariable() (VariableProduct, bool) {
+	return VariableProduct{p}, p.Type == ProductTypeVariable
+}
+
+// AsGrouped returns the grouped product view, ok is false for other types
+func (p *Product) AsGrouped() (GroupedProduct, bool) {
+	return GroupedProduct{p}, p.Type == ProductTypeGrouped
+}
+
+// AsExternal returns the external product view, ok is false for other types
+func (p *Product) AsExternal() (ExternalProduct, bool) {
+	return ExternalProduct{p}, p.Type == ProductTypeExternal
+}
+
+// VariationIDs returns the IDs of the product's variations
+func (v VariableProduct) VariationIDs() []int64 {
+	return v.Variations
+}
+
+// DefaultVariation returns the default option of each variation attribute, by name
+func (v VariableProduct) DefaultVariation() map[string]string {
+	defaults := make(map[string]string, len(v.DefaultAttributes))
+	for _, attribute := range v.DefaultAttributes {
+		defaults[attribute.Name] = attribute.Option
+	}
+	return defaults
+}
+
+// Children returns the IDs of the grouped products
+func (g GroupedProduct) Children() []int64 {
+	return g.GroupedProducts
+}
+
+// URL returns the external product url
+func (e ExternalProduct) URL() string {
+	return e.ExternalUrl
+}
+
+// Button returns the text of the buy button
+func (e ExternalProduct) Button() string {
+	return e.ButtonText
+}
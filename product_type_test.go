@@ -0,0 +1,86 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestProduct_Validate(t *testing.T) {
+	size := Attribute{Name: "Size", Variation: true, Options: []string{"S", "M"}}
+	tests := []struct {
+		name    string
+		product Product
+		wantErr string
+	}{
+		{"simple", NewSimpleProduct("Mug", "9.90"), ""},
+		{"no name", Product{RegularPrice: "9.90"}, ""},
+		{"bad price", NewSimpleProduct("Mug", "9,90"), "regular_price"},
+		{"variable", NewVariableProduct("Hoodie", size), ""},
+		{"variable without variation attribute", NewVariableProduct("Hoodie", Attribute{Name: "Size", Options: []string{"S"}}), "without variation attribute"},
+		{"variable without options", NewVariableProduct("Hoodie", Attribute{Name: "Size", Variation: true}), "without options"},
+		{"variable with price", Product{Type: ProductTypeVariable, Name: "Hoodie", RegularPrice: "10", Attributes: []Attribute{size}}, "set on its variations"},
+		{"grouped", NewGroupedProduct("Set", 12, 13), ""},
+		{"grouped without children", NewGroupedProduct("Set"), "without children"},
+		{"external", NewExternalProduct("Book", "https://example.com/book", "Buy", "15"), ""},
+		{"external relative url", NewExternalProduct("Book", "/book", "Buy", "15"), "external_url"},
+		{"external managed stock", Product{Type: ProductTypeExternal, Name: "Book", ExternalUrl: "https://example.com", ManageStock: StockManaged}, "can't be managed"},
+		{"variation", Product{Type: ProductTypeVariation, Name: "Hoodie - S"}, "ProductVariationService"},
+		{"plugin type", Product{Type: "subscription", Name: "Box"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.product.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProduct_StockAndSalesJSON(t *testing.T) {
+	var products []Product
+	data := `[{"manage_stock":true,"total_sales":"12"},{"manage_stock":"parent","total_sales":3},{"manage_stock":false,"total_sales":null}]`
+	if err := json.Unmarshal([]byte(data), &products); err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		stock StockManagement
+		sales Count
+	}{{StockManaged, 12}, {StockManagedByParent, 3}, {StockNotManaged, 0}}
+	for i, w := range want {
+		if products[i].ManageStock != w.stock || products[i].TotalSales != w.sales {
+			t.Errorf("product %d = %q, %d, want %q, %d", i, products[i].ManageStock, products[i].TotalSales, w.stock, w.sales)
+		}
+	}
+
+	for _, data := range []string{`{"total_sales":-1}`, `{"total_sales":"1.5"}`, `{"total_sales":"many"}`} {
+		var product Product
+		if err := json.Unmarshal([]byte(data), &product); err == nil {
+			t.Errorf("Unmarshal(%s) = %d, want an invalid count error", data, product.TotalSales)
+		}
+	}
+
+	for stock, want := range map[StockManagement]string{StockManaged: "true", StockNotManaged: "false", StockManagedByParent: `"parent"`} {
+		got, err := json.Marshal(stock)
+		if err != nil || string(got) != want {
+			t.Errorf("Marshal(%q) = %s, %v, want %s", stock, got, err, want)
+		}
+	}
+}
+
+func TestProductServiceOp_CreateValidates(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	if _, err := c.Product.Create(NewGroupedProduct("Set")); err == nil {
+		t.Fatal("Create() of an invalid grouped product succeeded")
+	}
+}
@@ -2,8 +2,7 @@ package woocommerce
 
 import (
 	"fmt"
-	"net/http"
-	"time"
+	"strings"
 )
 
 const (
@@ -13,12 +12,14 @@ const (
 // ProductVariationService is an interface for interfacing with the product variation endpoints of WooCommerce API
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#product-variations
 type ProductVariationService interface {
-	Create(productID int64, variation Product) (*Product, error)
-	Get(productID, variationID int64, options interface{}) (*Product, error)
-	List(productID int64, options interface{}) ([]Product, *Pagination, error)
-	Update(productID, variationID int64, variation *Product) (*Product, error)
-	Delete(productID, variationID int64, options interface{}) (*Product, error)
-	Batch(productID int64, data ProductBatchOption) (*ProductBatchResource, error)
+	Create(productID int64, variation ProductVariation) (*ProductVariation, error)
+	Get(productID, variationID int64, options interface{}) (*ProductVariation, error)
+	List(productID int64, options interface{}) ([]ProductVariation, *Pagination, error)
+	Update(productID, variationID int64, variation *ProductVariation) (*ProductVariation, error)
+	Patch(productID, variationID int64, patch Patch) (*ProductVariation, error)
+	Delete(productID, variationID int64, options interface{}) (*ProductVariation, error)
+	Batch(productID int64, data ProductVariationBatchOption) (*ProductVariationBatchResource, error)
+	BatchAll(productID int64, data ProductVariationBatchOption, options *BatchOption) (*BatchResult[ProductVariation], error)
 }
 
 // ProductVariationServiceOp handles communication with the product variation related methods of the WooCommerce API
@@ -27,80 +28,180 @@ type ProductVariationServiceOp struct {
 }
 
 // ProductVariationListOptions represents the optional parameters for listing variations
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#list-all-product-variations
 type ProductVariationListOptions struct {
 	ListOptions
-	ModifiedAfter  time.Time `json:"modified_after,omitempty" url:"modified_after,omitempty"`
-	ModifiedBefore time.Time `json:"modified_before,omitempty" url:"modified_before,omitempty"`
-	DatesAreGMT    bool      `json:"dates_are_gmt,omitempty" url:"dates_are_gmt,omitempty"`
-	Slug           string    `json:"slug,omitempty" url:"slug,omitempty"`
-	Status         string    `json:"status,omitempty" url:"status,omitempty"`
-	StockStatus    string    `json:"stock_status,omitempty" url:"stock_status,omitempty"`
-	MinPrice       string    `json:"min_price,omitempty" url:"min_price,omitempty"`
-	MaxPrice       string    `json:"max_price,omitempty" url:"max_price,omitempty"`
+	ModifiedAfter  Time    `json:"modified_after,omitempty" url:"modified_after,omitempty"`
+	ModifiedBefore Time    `json:"modified_before,omitempty" url:"modified_before,omitempty"`
+	DatesAreGMT    bool    `json:"dates_are_gmt,omitempty" url:"dates_are_gmt,omitempty"`
+	Parent         []int64 `json:"parent,omitempty" url:"parent,omitempty,comma"`
+	ParentExclude  []int64 `json:"parent_exclude,omitempty" url:"parent_exclude,omitempty,comma"`
+	Slug           string  `json:"slug,omitempty" url:"slug,omitempty"`
+	Status         string  `json:"status,omitempty" url:"status,omitempty"`
+	SKU            string  `json:"sku,omitempty" url:"sku,omitempty"`
+	TaxClass       string  `json:"tax_class,omitempty" url:"tax_class,omitempty"`
+	OnSale         *bool   `json:"on_sale,omitempty" url:"on_sale,omitempty"`
+	StockStatus    string  `json:"stock_status,omitempty" url:"stock_status,omitempty"`
+	MinPrice       string  `json:"min_price,omitempty" url:"min_price,omitempty"`
+	MaxPrice       string  `json:"max_price,omitempty" url:"max_price,omitempty"`
+}
+
+// ProductVariationBatchOption allows for batch operations on the variations of a product
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#batch-update-product-variations
+type ProductVariationBatchOption struct {
+	Create []ProductVariation `json:"create,omitempty"`
+	Update []ProductVariation `json:"update,omitempty"`
+	Delete []int64            `json:"delete,omitempty"`
+}
+
+// ProductVariationBatchResource handles the response struct for ProductVariationBatchOption request
+type ProductVariationBatchResource struct {
+	Create []*ProductVariation `json:"create,omitempty"`
+	Update []*ProductVariation `json:"update,omitempty"`
+	Delete []*ProductVariation `json:"delete,omitempty"`
+}
+
+// ProductVariation represents a variation of a variable product. Unlike a Product it has a
+// single image, attributes with the option of the variation and no categories or tags, and
+// its stock may be managed by the parent product.
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#product-variation-properties
+type ProductVariation struct {
+	ID                int64                `json:"id,omitempty"`
+	Name              string               `json:"name,omitempty"`
+	Type              string               `json:"type,omitempty"`
+	ParentID          int64                `json:"parent_id,omitempty"`
+	DateCreated       *Time                `json:"date_created,omitempty"`
+	DateCreatedGmt    *Time                `json:"date_created_gmt,omitempty"`
+	DateModified      *Time                `json:"date_modified,omitempty"`
+	DateModifiedGmt   *Time                `json:"date_modified_gmt,omitempty"`
+	Description       string               `json:"description,omitempty"`
+	Permalink         string               `json:"permalink,omitempty"`
+	Sku               string               `json:"sku,omitempty"`
+	Price             Amount               `json:"price,omitempty"`
+	RegularPrice      Amount               `json:"regular_price,omitempty"`
+	SalePrice         Amount               `json:"sale_price,omitempty"`
+	DateOnSaleFrom    *Time                `json:"date_on_sale_from,omitempty"`
+	DateOnSaleFromGmt *Time                `json:"date_on_sale_from_gmt,omitempty"`
+	DateOnSaleTo      *Time                `json:"date_on_sale_to,omitempty"`
+	DateOnSaleToGmt   *Time                `json:"date_on_sale_to_gmt,omitempty"`
+	OnSale            bool                 `json:"on_sale,omitempty"`
+	Status            string               `json:"status,omitempty"`
+	Purchasable       bool                 `json:"purchasable,omitempty"`
+	Virtual           bool                 `json:"virtual,omitempty"`
+	Downloadable      bool                 `json:"downloadable,omitempty"`
+	Downloads         []Download           `json:"downloads,omitempty"`
+	DownloadLimit     int                  `json:"download_limit,omitempty"`
+	DownloadExpiry    int                  `json:"download_expiry,omitempty"`
+	TaxStatus         string               `json:"tax_status,omitempty"`
+	TaxClass          string               `json:"tax_class,omitempty"`
+	ManageStock       StockManagement      `json:"manage_stock,omitempty"`
+	StockQuantity     *int                 `json:"stock_quantity,omitempty"`
+	StockStatus       string               `json:"stock_status,omitempty"`
+	Backorders        string               `json:"backorders,omitempty"`
+	BackordersAllowed bool                 `json:"backorders_allowed,omitempty"`
+	Backordered       bool                 `json:"backordered,omitempty"`
+	Weight            string               `json:"weight,omitempty"`
+	Dimensions        *Dimensions          `json:"dimensions,omitempty"`
+	ShippingClass     string               `json:"shipping_class,omitempty"`
+	ShippingClassId   int64                `json:"shipping_class_id,omitempty"`
+	Image             *Image               `json:"image,omitempty"`
+	Attributes        []VariationAttribute `json:"attributes,omitempty"`
+	MenuOrder         int                  `json:"menu_order,omitempty"`
+	MetaData          MetaDataList         `json:"meta_data,omitempty"`
+	Links             Links                `json:"_links,omitempty"`
+	Extra             Extra                `json:"-"`
+}
+
+// VariationAttribute is the option of an attribute of the parent product that identifies the
+// variation. ID is 0 for custom attributes, matched by Name.
+type VariationAttribute struct {
+	ID     int64  `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Option string `json:"option,omitempty"`
+	Extra  Extra  `json:"-"`
+}
+
+// Option returns the option of the attribute with name, matched case insensitively
+func (v *ProductVariation) Option(name string) (string, bool) {
+	for _, attribute := range v.Attributes {
+		if strings.EqualFold(attribute.Name, name) {
+			return attribute.Option, true
+		}
+	}
+	return "", false
 }
 
 // Create new product variation
-func (p *ProductVariationServiceOp) Create(productID int64, variation Product) (*Product, error) {
+func (p *ProductVariationServiceOp) Create(productID int64, variation ProductVariation) (*ProductVariation, error) {
 	path := fmt.Sprintf(variationsBasePath, productID)
-	resource := new(Product)
+	resource := new(ProductVariation)
 	err := p.client.Post(path, variation, &resource)
 	return resource, err
 }
 
 // Get individual product variation
-func (p *ProductVariationServiceOp) Get(productID, variationID int64, options interface{}) (*Product, error) {
+func (p *ProductVariationServiceOp) Get(productID, variationID int64, options interface{}) (*ProductVariation, error) {
 	path := fmt.Sprintf("%s/%d", fmt.Sprintf(variationsBasePath, productID), variationID)
-	resource := new(Product)
+	resource := new(ProductVariation)
 	err := p.client.Get(path, resource, options)
 	return resource, err
 }
 
 // List product variations
-func (p *ProductVariationServiceOp) List(productID int64, options interface{}) ([]Product, *Pagination, error) {
+func (p *ProductVariationServiceOp) List(productID int64, options interface{}) ([]ProductVariation, *Pagination, error) {
 	variations, pagination, err := p.ListWithPagination(productID, options)
 	return variations, pagination, err
 }
 
 // ListWithPagination lists product variations and returns pagination to retrieve next/previous results.
-func (p *ProductVariationServiceOp) ListWithPagination(productID int64, options interface{}) ([]Product, *Pagination, error) {
+func (p *ProductVariationServiceOp) ListWithPagination(productID int64, options interface{}) ([]ProductVariation, *Pagination, error) {
 	path := fmt.Sprintf(variationsBasePath, productID)
-	resource := make([]Product, 0)
-	headers := http.Header{}
+	resource := make([]ProductVariation, 0)
 	headers, err := p.client.createAndDoGetHeaders("GET", path, nil, options, &resource)
 	if err != nil {
 		return nil, nil, err
 	}
-	// Extract pagination info from header
-	linkHeader := headers.Get("Link")
-	fmt.Println(linkHeader)
-	pagination, err := extractPagination(linkHeader)
+	pagination, err := extractPaginationHeaders(headers)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	return resource, pagination, err
+	return resource, pagination, nil
 }
 
 // Update existing product variation
-func (p *ProductVariationServiceOp) Update(productID, variationID int64, variation *Product) (*Product, error) {
+func (p *ProductVariationServiceOp) Update(productID, variationID int64, variation *ProductVariation) (*ProductVariation, error) {
 	path := fmt.Sprintf("%s/%d", fmt.Sprintf(variationsBasePath, productID), variationID)
-	resource := new(Product)
+	resource := new(ProductVariation)
 	err := p.client.Put(path, variation, &resource)
 	return resource, err
 }
 
+// Patch updates the listed properties of a product variation, see Patch
+func (p *ProductVariationServiceOp) Patch(productID, variationID int64, patch Patch) (*ProductVariation, error) {
+	path := fmt.Sprintf("%s/%d", fmt.Sprintf(variationsBasePath, productID), variationID)
+	resource := new(ProductVariation)
+	err := p.client.Put(path, patch, &resource)
+	return resource, err
+}
+
 // Delete existing product variation
-func (p *ProductVariationServiceOp) Delete(productID, variationID int64, options interface{}) (*Product, error) {
+func (p *ProductVariationServiceOp) Delete(productID, variationID int64, options interface{}) (*ProductVariation, error) {
 	path := fmt.Sprintf("%s/%d", fmt.Sprintf(variationsBasePath, productID), variationID)
-	resource := new(Product)
+	resource := new(ProductVariation)
 	err := p.client.Delete(path, options, &resource)
 	return resource, err
 }
 
-// Batch implements ProductVariationService.
-func (p *ProductVariationServiceOp) Batch(productID int64, data ProductBatchOption) (*ProductBatchResource, error) {
-	path := fmt.Sprintf("%s/%d/variations/batch", productsBasePath, productID)
-	resource := new(ProductBatchResource)
+// Batch creates, updates and deletes variations of a product
+func (p *ProductVariationServiceOp) Batch(productID int64, data ProductVariationBatchOption) (*ProductVariationBatchResource, error) {
+	path := fmt.Sprintf("%s/batch", fmt.Sprintf(variationsBasePath, productID))
+	resource := new(ProductVariationBatchResource)
 	err := p.client.Post(path, data, &resource)
 	return resource, err
 }
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each variation
+func (p *ProductVariationServiceOp) BatchAll(productID int64, data ProductVariationBatchOption, options *BatchOption) (*BatchResult[ProductVariation], error) {
+	path := fmt.Sprintf("%s/batch", fmt.Sprintf(variationsBasePath, productID))
+	return executeBatch(p.client, p.client.pathPrefix, path, BatchRequest[ProductVariation](data), options)
+}
@@ -0,0 +1,82 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const variationJSON = `{
+	"id": 733,
+	"parent_id": 732,
+	"type": "variation",
+	"sku": "hoodie-blue-m",
+	"regular_price": "45.00",
+	"manage_stock": "parent",
+	"stock_quantity": null,
+	"image": {"id": 56, "src": "https://example.com/hoodie-blue.jpg"},
+	"attributes": [{"id": 6, "name": "Color", "option": "Blue"}, {"id": 0, "name": "Size", "option": "M"}],
+	"meta_data": []
+}`
+
+func TestProductVariationServiceOp_Update(t *testing.T) {
+	var body string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/wp-json/wc/v3/products/732/variations/733" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		io.WriteString(w, variationJSON)
+	}))
+
+	variation, err := c.ProductVariation.Update(732, 733, &ProductVariation{
+		RegularPrice: "45.00",
+		ManageStock:  StockManagedByParent,
+		Image:        &Image{Id: 56},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, product := range []string{"categories", "tags", "images", "grouped_products", "total_sales"} {
+		if strings.Contains(body, product) {
+			t.Errorf("update body %s has product property %s", body, product)
+		}
+	}
+	if !strings.Contains(body, `"manage_stock":"parent"`) || !strings.Contains(body, `"image":{"id":56}`) {
+		t.Errorf("update body = %s", body)
+	}
+
+	if variation.ParentID != 732 || variation.ManageStock != StockManagedByParent || variation.StockQuantity != nil {
+		t.Errorf("variation = %+v", variation)
+	}
+	if variation.Image == nil || variation.Image.Id != 56 {
+		t.Errorf("variation image = %+v", variation.Image)
+	}
+	if size, ok := variation.Option("size"); !ok || size != "M" {
+		t.Errorf("Option(size) = %q, %v", size, ok)
+	}
+}
+
+func TestProductVariation_RoundTrip(t *testing.T) {
+	var variation ProductVariation
+	if err := json.Unmarshal([]byte(variationJSON), &variation); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(variation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want map[string]interface{}
+	json.Unmarshal(data, &got)
+	json.Unmarshal([]byte(variationJSON), &want)
+	for _, key := range []string{"id", "parent_id", "sku", "regular_price", "manage_stock", "image"} {
+		g, _ := json.Marshal(got[key])
+		w, _ := json.Marshal(want[key])
+		if string(g) != string(w) {
+			t.Errorf("%s = %s, want %s", key, g, w)
+		}
+	}
+}
@@ -14,8 +14,9 @@ type OrderRefundService interface {
 type OrderRefund struct {
 	ID int64 `json:"id,omitempty"`
 
-	DateCreated    string `json:"date_created,omitempty"`
-	DateCreatedGmt string `json:"date_created_gmt,omitempty"`
+	DateCreated    *Time `json:"date_created,omitempty"`
+	DateCreatedGmt *Time `json:"date_created_gmt,omitempty"`
 
-	Amount string `json:"amount,omitempty"`
+	Amount Amount `json:"amount,omitempty"`
+	Extra  Extra  `json:"-"`
 }
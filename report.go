@@ -29,6 +29,7 @@ type Report struct {
 	ID    string `json:"id,omitempty"`
 	Title string `json:"title,omitempty"`
 	Total string `json:"total,omitempty"`
+	Extra Extra  `json:"-"`
 }
 
 // TotalOrdersReport represents a report for total orders
@@ -36,6 +37,7 @@ type TotalOrdersReport struct {
 	Slug  string `json:"slug"`
 	Name  string `json:"name"`
 	Total int    `json:"total"`
+	Extra Extra  `json:"-"`
 }
 
 // TotalCustomersReport represents a report for total customers
@@ -43,6 +45,7 @@ type TotalCustomersReport struct {
 	Slug  string `json:"slug"`
 	Name  string `json:"name"`
 	Total int    `json:"total"`
+	Extra Extra  `json:"-"`
 }
 
 // TotalProductsReport represents a report for total products
@@ -50,6 +53,7 @@ type TotalProductsReport struct {
 	Slug  string `json:"slug"`
 	Name  string `json:"name"`
 	Total int    `json:"total"`
+	Extra Extra  `json:"-"`
 }
 
 // Get individual report
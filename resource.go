@@ -0,0 +1,123 @@
+package woocommerce
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource is a service for a REST collection of resources of type T. The order, product,
+// customer, coupon and webhook services are built on it, except for their Batch methods which
+// keep their own response types, and so can the endpoints of plugins without a dedicated service:
+//
+//	type Subscription struct {
+//		ID     int64  `json:"id,omitempty"`
+//		Status string `json:"status,omitempty"`
+//	}
+//
+//	subscriptions := woocommerce.NewResource[Subscription](client, "subscriptions", woocommerce.WithNamespace("wc/v1"))
+//	list, pagination, err := subscriptions.List(woocommerce.ListOptions{PerPage: 50})
+type Resource[T any] struct {
+	client   *Client
+	basePath string
+	prefix   string
+}
+
+// ResourceOption configures a Resource
+type ResourceOption func(r *resourceConfig)
+
+type resourceConfig struct {
+	prefix string
+}
+
+// WithNamespace sets the REST namespace of the resource, e.g. "wc/v1" or "wc-bookings/v1",
+// instead of the client's
+func WithNamespace(namespace string) ResourceOption {
+	return func(r *resourceConfig) {
+		r.prefix = "/wp-json/" + strings.Trim(namespace, "/")
+	}
+}
+
+// NewResource returns the service of the resources at basePath, e.g. "subscriptions"
+func NewResource[T any](c *Client, basePath string, opts ...ResourceOption) *Resource[T] {
+	config := resourceConfig{prefix: c.pathPrefix}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return &Resource[T]{client: c, basePath: strings.Trim(basePath, "/"), prefix: config.prefix}
+}
+
+// Create a resource
+func (r *Resource[T]) Create(resource T) (*T, error) {
+	created := new(T)
+	_, err := r.client.createAndDoWithPrefix(r.prefix, "POST", r.basePath, resource, nil, created)
+	return created, err
+}
+
+// Get a resource
+func (r *Resource[T]) Get(id int64, options interface{}) (*T, error) {
+	resource := new(T)
+	_, err := r.client.createAndDoWithPrefix(r.prefix, "GET", r.path(id), nil, options, resource)
+	return resource, err
+}
+
+// List resources, with the pagination to retrieve the other pages
+func (r *Resource[T]) List(options interface{}) ([]T, *Pagination, error) {
+	resources := make([]T, 0)
+	headers, err := r.client.createAndDoWithPrefix(r.prefix, "GET", r.basePath, nil, options, &resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	pagination, err := extractPaginationHeaders(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resources, pagination, nil
+}
+
+// ListAll lists the resources of every page, options must not set the page
+func (r *Resource[T]) ListAll(options ListOptions) ([]T, error) {
+	if options.PerPage == 0 {
+		options.PerPage = maxPerPage
+	}
+	resources := make([]T, 0)
+	for options.Page = 1; ; options.Page++ {
+		list, pagination, err := r.List(options)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, list...)
+		if pagination.NextPageOptions == nil && (pagination.TotalPages < 0 || options.Page >= pagination.TotalPages) {
+			return resources, nil
+		}
+	}
+}
+
+// Update a resource
+func (r *Resource[T]) Update(id int64, resource *T) (*T, error) {
+	updated := new(T)
+	_, err := r.client.createAndDoWithPrefix(r.prefix, "PUT", r.path(id), resource, nil, updated)
+	return updated, err
+}
+
+// Patch updates the listed properties of a resource, see Patch
+func (r *Resource[T]) Patch(id int64, patch Patch) (*T, error) {
+	updated := new(T)
+	_, err := r.client.createAndDoWithPrefix(r.prefix, "PUT", r.path(id), patch, nil, updated)
+	return updated, err
+}
+
+// Delete a resource, options is e.g. DeleteOption{Force: true}
+func (r *Resource[T]) Delete(id int64, options interface{}) (*T, error) {
+	deleted := new(T)
+	_, err := r.client.createAndDoWithPrefix(r.prefix, "DELETE", r.path(id), nil, options, deleted)
+	return deleted, err
+}
+
+// Batch creates, updates and deletes resources in chunks WooCommerce accepts, see BatchOption
+func (r *Resource[T]) Batch(data BatchRequest[T], options *BatchOption) (*BatchResult[T], error) {
+	return executeBatch(r.client, r.prefix, r.basePath+"/batch", data, options)
+}
+
+func (r *Resource[T]) path(id int64) string {
+	return fmt.Sprintf("%s/%d", r.basePath, id)
+}
@@ -0,0 +1,97 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+type testSubscription struct {
+	ID     int64  `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+func TestResource(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wc/v1/subscriptions":
+			page := r.URL.Query().Get("page")
+			w.Header().Set("X-WP-Total", "3")
+			w.Header().Set("X-WP-TotalPages", "2")
+			if page == "1" {
+				w.Header().Set("Link", fmt.Sprintf(`<https://%s/wp-json/wc/v1/subscriptions?page=2&per_page=2>; rel="next"`, r.Host))
+				fmt.Fprint(w, `[{"id":1,"status":"active"},{"id":2,"status":"on-hold"}]`)
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/wp-json/wc/v1/subscriptions?page=1&per_page=2>; rel="prev"`, r.Host))
+			fmt.Fprint(w, `[{"id":3,"status":"active"}]`)
+		case "PUT /wp-json/wc/v1/subscriptions/2":
+			var s testSubscription
+			json.NewDecoder(r.Body).Decode(&s)
+			s.ID = 2
+			json.NewEncoder(w).Encode(s)
+		case "POST /wp-json/wc/v1/subscriptions/batch":
+			fmt.Fprint(w, `{"delete":[{"id":3,"status":"cancelled"}]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	subscriptions := NewResource[testSubscription](c, "subscriptions", WithNamespace("wc/v1"))
+
+	list, pagination, err := subscriptions.List(ListOptions{Page: 1, PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || pagination.Total != 3 || pagination.TotalPages != 2 {
+		t.Errorf("List() = %v, %+v", list, pagination)
+	}
+	if pagination.NextPageOptions == nil || pagination.NextPageOptions.Page != 2 {
+		t.Errorf("next page = %+v", pagination.NextPageOptions)
+	}
+
+	all, err := subscriptions.ListAll(ListOptions{PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[2].ID != 3 {
+		t.Errorf("ListAll() = %v", all)
+	}
+
+	updated, err := subscriptions.Update(2, &testSubscription{Status: "active"})
+	if err != nil || updated.ID != 2 || updated.Status != "active" {
+		t.Errorf("Update() = %+v, %v", updated, err)
+	}
+
+	result, err := subscriptions.Batch(BatchRequest[testSubscription]{Delete: []int64{3}}, nil)
+	if err != nil || len(result.Delete) != 1 || result.Delete[0].Resource.Status != "cancelled" {
+		t.Errorf("Batch() = %+v, %v", result, err)
+	}
+}
+
+func TestServices_ListWithPages(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-WP-Total", "12")
+		w.Header().Set("X-WP-TotalPages", "2")
+		w.Header().Set("Link", fmt.Sprintf(`<https://%s%s?page=2&per_page=10>; rel="next"`, r.Host, r.URL.Path))
+		fmt.Fprint(w, `[{"id":1}]`)
+	}))
+
+	orders, pagination, err := c.Order.ListWithPages(OrderListOption{ListOptions: ListOptions{PerPage: 10}})
+	if err != nil || len(orders) != 1 || pagination.Total != 12 || pagination.NextPageOptions.Page != 2 {
+		t.Errorf("orders = %v, %+v, %v", orders, pagination, err)
+	}
+	products, pagination, err := c.Product.ListWithPages(nil)
+	if err != nil || len(products) != 1 || pagination.TotalPages != 2 {
+		t.Errorf("products = %v, %+v, %v", products, pagination, err)
+	}
+	customers, pagination, err := c.Customer.ListWithPages(nil)
+	if err != nil || len(customers) != 1 || pagination.TotalPages != 2 {
+		t.Errorf("customers = %v, %+v, %v", customers, pagination, err)
+	}
+	coupons, pagination, err := c.Coupon.ListWithPages(CouponListOption{Code: "summer"})
+	if err != nil || len(coupons) != 1 || pagination.TotalPages != 2 {
+		t.Errorf("coupons = %v, %+v, %v", coupons, pagination, err)
+	}
+}
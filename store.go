@@ -0,0 +1,208 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+const (
+	storeApiPathPrefix = "/wp-json/wc/store/v1"
+
+	// Store API session headers, the nonce protects cart writes and the cart token identifies
+	// the cart of a headless client that doesn't keep the WooCommerce session cookies.
+	storeNonceHeader       = "Nonce"
+	storeLegacyNonceHeader = "X-WC-Store-API-Nonce"
+	storeCartTokenHeader   = "Cart-Token"
+)
+
+// StoreClient is a client for the public WooCommerce Store API (wc/store/v1), used by
+// storefronts to browse products, manage a cart and check out. Unlike Client it is not
+// authenticated with API keys; it keeps the shopper's session through the Nonce and
+// Cart-Token headers WooCommerce returns.
+// https://github.com/woocommerce/woocommerce/tree/trunk/plugins/woocommerce/src/StoreApi/docs
+type StoreClient struct {
+	Client     *http.Client
+	log        LeveledLoggerInterface
+	baseURL    *url.URL
+	pathPrefix string
+
+	mu        sync.Mutex
+	nonce     string
+	cartToken string
+
+	Product  StoreProductService
+	Cart     StoreCartService
+	Checkout StoreCheckoutService
+}
+
+type StoreOption func(s *StoreClient)
+
+// WithStoreCartToken resumes the cart identified by a previously returned Cart-Token
+func WithStoreCartToken(token string) StoreOption {
+	return func(s *StoreClient) {
+		s.cartToken = token
+	}
+}
+
+// WithStoreLog log config option
+func WithStoreLog(logger LeveledLoggerInterface) StoreOption {
+	return func(s *StoreClient) {
+		s.log = logger
+	}
+}
+
+// NewStoreClient returns a new Store API client. The shopName parameter is the shop's
+// base url, e.g. "https://shop.gitvim.com"
+func NewStoreClient(shopName string, opts ...StoreOption) *StoreClient {
+	baseURL, err := url.Parse(shopName)
+	if err != nil {
+		panic(err)
+	}
+	s := &StoreClient{
+		Client: &http.Client{
+			Timeout: time.Second * defaultHttpTimeout,
+		},
+		log:        &LeveledLogger{},
+		baseURL:    baseURL,
+		pathPrefix: storeApiPathPrefix,
+	}
+
+	s.Product = &StoreProductServiceOp{client: s}
+	s.Cart = &StoreCartServiceOp{client: s}
+	s.Checkout = &StoreCheckoutServiceOp{client: s}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CartToken returns the token of the current cart, store it to resume the cart later
+// with WithStoreCartToken.
+func (s *StoreClient) CartToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cartToken
+}
+
+// Nonce returns the current Store API nonce
+func (s *StoreClient) Nonce() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nonce
+}
+
+// NewRequest creates a Store API request for the relative path, JSON encoding body and
+// attaching the session headers.
+func (s *StoreClient) NewRequest(method, relPath string, body, options interface{}) (*http.Request, error) {
+	rel, err := url.Parse(path.Join(s.pathPrefix, strings.TrimLeft(relPath, "/")))
+	if err != nil {
+		return nil, err
+	}
+	u := s.baseURL.ResolveReference(rel)
+
+	if options != nil {
+		optionsQuery, err := query.Values(options)
+		if err != nil {
+			return nil, err
+		}
+		u.RawQuery = optionsQuery.Encode()
+	}
+
+	var js []byte = nil
+	if body != nil {
+		js, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("User-Agent", UserAgent)
+
+	s.mu.Lock()
+	if s.nonce != "" {
+		req.Header.Set(storeNonceHeader, s.nonce)
+	}
+	if s.cartToken != "" {
+		req.Header.Set(storeCartTokenHeader, s.cartToken)
+	}
+	s.mu.Unlock()
+	return req, nil
+}
+
+// Do sends a Store API request, keeps the session headers of the response and decodes the
+// body into v.
+func (s *StoreClient) Do(req *http.Request, v interface{}) error {
+	s.log.Debugf("%s: %s", req.Method, req.URL.String())
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	s.log.Debugf("RECV %d: %s", resp.StatusCode, resp.Status)
+
+	s.keepSession(resp.Header)
+
+	if err := CheckResponseError(resp); err != nil {
+		return err
+	}
+
+	if v != nil {
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+	return nil
+}
+
+// keepSession stores the nonce and cart token sent by the Store API
+func (s *StoreClient) keepSession(header http.Header) {
+	nonce := header.Get(storeNonceHeader)
+	if nonce == "" {
+		nonce = header.Get(storeLegacyNonceHeader)
+	}
+	cartToken := header.Get(storeCartTokenHeader)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nonce != "" {
+		s.nonce = nonce
+	}
+	if cartToken != "" {
+		s.cartToken = cartToken
+	}
+}
+
+// CreateAndDo performs a Store API request with the given method and relative path
+func (s *StoreClient) CreateAndDo(method, relPath string, data, options, resource interface{}) error {
+	req, err := s.NewRequest(method, relPath, data, options)
+	if err != nil {
+		return err
+	}
+	return s.Do(req, resource)
+}
+
+// Get performs a GET request for the given path and saves the result in the
+// given resource.
+func (s *StoreClient) Get(path string, resource, options interface{}) error {
+	return s.CreateAndDo("GET", path, nil, options, resource)
+}
+
+// Post performs a POST request for the given path and saves the result in the
+// given resource.
+func (s *StoreClient) Post(path string, data, resource interface{}) error {
+	return s.CreateAndDo("POST", path, data, nil, resource)
+}
@@ -0,0 +1,256 @@
+package woocommerce
+
+const (
+	storeCartBasePath     = "cart"
+	storeCheckoutBasePath = "checkout"
+)
+
+// StoreCartService is an interface for the cart endpoints of the Store API. Every write
+// returns the updated cart.
+type StoreCartService interface {
+	Get() (*Cart, error)
+	AddItem(item CartItemRequest) (*Cart, error)
+	UpdateItem(key string, quantity int) (*Cart, error)
+	RemoveItem(key string) (*Cart, error)
+	ApplyCoupon(code string) (*Cart, error)
+	RemoveCoupon(code string) (*Cart, error)
+	SelectShippingRate(packageID int, rateID string) (*Cart, error)
+	UpdateCustomer(billing *Billing, shipping *Shipping) (*Cart, error)
+}
+
+// StoreCartServiceOp handles communication with the cart related methods of the Store API
+type StoreCartServiceOp struct {
+	client *StoreClient
+}
+
+// StoreCheckoutService is an interface for the checkout endpoints of the Store API
+type StoreCheckoutService interface {
+	Get() (*CheckoutOrder, error)
+	Process(checkout Checkout) (*CheckoutOrder, error)
+}
+
+// StoreCheckoutServiceOp handles communication with the checkout related methods of the Store API
+type StoreCheckoutServiceOp struct {
+	client *StoreClient
+}
+
+// Cart represents the shopper's cart
+type Cart struct {
+	Items                 []CartItem         `json:"items,omitempty"`
+	Coupons               []CartCoupon       `json:"coupons,omitempty"`
+	Fees                  []CartFee          `json:"fees,omitempty"`
+	ShippingRates         []CartShippingRate `json:"shipping_rates,omitempty"`
+	ShippingAddress       *Shipping          `json:"shipping_address,omitempty"`
+	BillingAddress        *Billing           `json:"billing_address,omitempty"`
+	NeedsPayment          bool               `json:"needs_payment,omitempty"`
+	NeedsShipping         bool               `json:"needs_shipping,omitempty"`
+	HasCalculatedShipping bool               `json:"has_calculated_shipping,omitempty"`
+	PaymentMethods        []string           `json:"payment_methods,omitempty"`
+	ItemsCount            int                `json:"items_count,omitempty"`
+	ItemsWeight           float64            `json:"items_weight,omitempty"`
+	Totals                *CartTotals        `json:"totals,omitempty"`
+	Errors                []CartError        `json:"errors,omitempty"`
+	Extra                 Extra              `json:"-"`
+}
+
+// CartItem is a line of the cart, Key identifies it in UpdateItem and RemoveItem
+type CartItem struct {
+	Key               string                 `json:"key,omitempty"`
+	ID                int64                  `json:"id,omitempty"`
+	Quantity          int                    `json:"quantity,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	ShortDescription  string                 `json:"short_description,omitempty"`
+	Sku               string                 `json:"sku,omitempty"`
+	Permalink         string                 `json:"permalink,omitempty"`
+	Images            []StoreImage           `json:"images,omitempty"`
+	Variation         []StoreVariationOption `json:"variation,omitempty"`
+	Prices            *StorePrices           `json:"prices,omitempty"`
+	Totals            *CartItemTotals        `json:"totals,omitempty"`
+	LowStockRemaining *int                   `json:"low_stock_remaining,omitempty"`
+	SoldIndividually  bool                   `json:"sold_individually,omitempty"`
+	Extra             Extra                  `json:"-"`
+}
+
+type CartItemTotals struct {
+	LineSubtotal    string `json:"line_subtotal,omitempty"`
+	LineSubtotalTax string `json:"line_subtotal_tax,omitempty"`
+	LineTotal       string `json:"line_total,omitempty"`
+	LineTotalTax    string `json:"line_total_tax,omitempty"`
+	StoreCurrency
+	Extra Extra `json:"-"`
+}
+
+type CartCoupon struct {
+	Code         string `json:"code,omitempty"`
+	DiscountType string `json:"discount_type,omitempty"`
+	Totals       *struct {
+		TotalDiscount    string `json:"total_discount,omitempty"`
+		TotalDiscountTax string `json:"total_discount_tax,omitempty"`
+		StoreCurrency
+	} `json:"totals,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+type CartFee struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Totals *struct {
+		Total    string `json:"total,omitempty"`
+		TotalTax string `json:"total_tax,omitempty"`
+		StoreCurrency
+	} `json:"totals,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+// CartShippingRate is a shipping package of the cart and the rates available for it
+type CartShippingRate struct {
+	PackageID     interface{}    `json:"package_id,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Destination   *Shipping      `json:"destination,omitempty"`
+	ShippingRates []ShippingRate `json:"shipping_rates,omitempty"`
+	Extra         Extra          `json:"-"`
+}
+
+type ShippingRate struct {
+	RateID       string `json:"rate_id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	DeliveryTime string `json:"delivery_time,omitempty"`
+	Price        string `json:"price,omitempty"`
+	Taxes        string `json:"taxes,omitempty"`
+	InstanceID   int64  `json:"instance_id,omitempty"`
+	MethodID     string `json:"method_id,omitempty"`
+	Selected     bool   `json:"selected,omitempty"`
+	StoreCurrency
+	Extra Extra `json:"-"`
+}
+
+type CartTotals struct {
+	TotalItems       string `json:"total_items,omitempty"`
+	TotalItemsTax    string `json:"total_items_tax,omitempty"`
+	TotalFees        string `json:"total_fees,omitempty"`
+	TotalFeesTax     string `json:"total_fees_tax,omitempty"`
+	TotalDiscount    string `json:"total_discount,omitempty"`
+	TotalDiscountTax string `json:"total_discount_tax,omitempty"`
+	TotalShipping    string `json:"total_shipping,omitempty"`
+	TotalShippingTax string `json:"total_shipping_tax,omitempty"`
+	TotalPrice       string `json:"total_price,omitempty"`
+	TotalTax         string `json:"total_tax,omitempty"`
+	StoreCurrency
+	Extra Extra `json:"-"`
+}
+
+type CartError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Extra   Extra  `json:"-"`
+}
+
+// CartItemRequest adds a product to the cart, Variation selects the attributes of a
+// variable product.
+type CartItemRequest struct {
+	ID        int64                  `json:"id"`
+	Quantity  int                    `json:"quantity"`
+	Variation []StoreVariationOption `json:"variation,omitempty"`
+}
+
+// Checkout is the request of the checkout endpoint
+type Checkout struct {
+	BillingAddress  *Billing          `json:"billing_address,omitempty"`
+	ShippingAddress *Shipping         `json:"shipping_address,omitempty"`
+	CustomerNote    string            `json:"customer_note,omitempty"`
+	CreateAccount   bool              `json:"create_account,omitempty"`
+	PaymentMethod   string            `json:"payment_method,omitempty"`
+	PaymentData     []CheckoutKeyPair `json:"payment_data,omitempty"`
+}
+
+type CheckoutKeyPair struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CheckoutOrder is the draft order of the checkout, after processing PaymentResult holds
+// the outcome of the payment.
+type CheckoutOrder struct {
+	OrderID         int64     `json:"order_id,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	OrderKey        string    `json:"order_key,omitempty"`
+	CustomerNote    string    `json:"customer_note,omitempty"`
+	CustomerID      int64     `json:"customer_id,omitempty"`
+	BillingAddress  *Billing  `json:"billing_address,omitempty"`
+	ShippingAddress *Shipping `json:"shipping_address,omitempty"`
+	PaymentMethod   string    `json:"payment_method,omitempty"`
+	PaymentResult   *struct {
+		PaymentStatus  string            `json:"payment_status,omitempty"`
+		PaymentDetails []CheckoutKeyPair `json:"payment_details,omitempty"`
+		RedirectUrl    string            `json:"redirect_url,omitempty"`
+	} `json:"payment_result,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+// Get returns the current cart, creating the session on the first call
+func (c *StoreCartServiceOp) Get() (*Cart, error) {
+	resource := new(Cart)
+	err := c.client.Get(storeCartBasePath, resource, nil)
+	return resource, err
+}
+
+// AddItem adds a product or variation to the cart
+func (c *StoreCartServiceOp) AddItem(item CartItemRequest) (*Cart, error) {
+	return c.post("add-item", item)
+}
+
+// UpdateItem changes the quantity of a cart item
+func (c *StoreCartServiceOp) UpdateItem(key string, quantity int) (*Cart, error) {
+	return c.post("update-item", map[string]interface{}{"key": key, "quantity": quantity})
+}
+
+// RemoveItem removes an item from the cart
+func (c *StoreCartServiceOp) RemoveItem(key string) (*Cart, error) {
+	return c.post("remove-item", map[string]string{"key": key})
+}
+
+// ApplyCoupon applies a coupon code to the cart
+func (c *StoreCartServiceOp) ApplyCoupon(code string) (*Cart, error) {
+	return c.post("apply-coupon", map[string]string{"code": code})
+}
+
+// RemoveCoupon removes a coupon code from the cart
+func (c *StoreCartServiceOp) RemoveCoupon(code string) (*Cart, error) {
+	return c.post("remove-coupon", map[string]string{"code": code})
+}
+
+// SelectShippingRate chooses the shipping rate of a shipping package
+func (c *StoreCartServiceOp) SelectShippingRate(packageID int, rateID string) (*Cart, error) {
+	return c.post("select-shipping-rate", map[string]interface{}{"package_id": packageID, "rate_id": rateID})
+}
+
+// UpdateCustomer sets the addresses used to calculate taxes and shipping
+func (c *StoreCartServiceOp) UpdateCustomer(billing *Billing, shipping *Shipping) (*Cart, error) {
+	data := struct {
+		BillingAddress  *Billing  `json:"billing_address,omitempty"`
+		ShippingAddress *Shipping `json:"shipping_address,omitempty"`
+	}{billing, shipping}
+	return c.post("update-customer", data)
+}
+
+func (c *StoreCartServiceOp) post(action string, data interface{}) (*Cart, error) {
+	path := storeCartBasePath + "/" + action
+	resource := new(Cart)
+	err := c.client.Post(path, data, resource)
+	return resource, err
+}
+
+// Get returns the draft order of the current cart
+func (c *StoreCheckoutServiceOp) Get() (*CheckoutOrder, error) {
+	resource := new(CheckoutOrder)
+	err := c.client.Get(storeCheckoutBasePath, resource, nil)
+	return resource, err
+}
+
+// Process places the order and processes the payment
+func (c *StoreCheckoutServiceOp) Process(checkout Checkout) (*CheckoutOrder, error) {
+	resource := new(CheckoutOrder)
+	err := c.client.Post(storeCheckoutBasePath, checkout, resource)
+	return resource, err
+}
@@ -0,0 +1,164 @@
+package woocommerce
+
+import "fmt"
+
+const (
+	storeProductsBasePath = "products"
+)
+
+// StoreProductService is an interface for the public products endpoints of the Store API
+type StoreProductService interface {
+	List(options interface{}) ([]StoreProduct, error)
+	Get(productID int64) (*StoreProduct, error)
+}
+
+// StoreProductServiceOp handles communication with the product related methods of the Store API
+type StoreProductServiceOp struct {
+	client *StoreClient
+}
+
+// StoreProductListOptions are the request params of the Store API product list
+type StoreProductListOptions struct {
+	Page          int      `url:"page,omitempty"`
+	PerPage       int      `url:"per_page,omitempty"`
+	Search        string   `url:"search,omitempty"`
+	Slug          string   `url:"slug,omitempty"`
+	Include       []int64  `url:"include,omitempty,comma"`
+	Exclude       []int64  `url:"exclude,omitempty,comma"`
+	Order         string   `url:"order,omitempty"`
+	Orderby       string   `url:"orderby,omitempty"`
+	Parent        []int64  `url:"parent,omitempty,comma"`
+	Type          string   `url:"type,omitempty"`
+	SKU           string   `url:"sku,omitempty"`
+	Featured      bool     `url:"featured,omitempty"`
+	Category      string   `url:"category,omitempty"`
+	Tag           string   `url:"tag,omitempty"`
+	OnSale        bool     `url:"on_sale,omitempty"`
+	MinPrice      string   `url:"min_price,omitempty"`
+	MaxPrice      string   `url:"max_price,omitempty"`
+	StockStatus   []string `url:"stock_status,omitempty,brackets"`
+	Visibility    string   `url:"catalog_visibility,omitempty"`
+	Rating        []int    `url:"rating,omitempty,brackets"`
+	AttributeTerm string   `url:"attribute_term,omitempty"`
+}
+
+// StoreProduct represents a product as exposed to shoppers by the Store API
+type StoreProduct struct {
+	ID                int64                   `json:"id,omitempty"`
+	Name              string                  `json:"name,omitempty"`
+	Slug              string                  `json:"slug,omitempty"`
+	Parent            int64                   `json:"parent,omitempty"`
+	Type              string                  `json:"type,omitempty"`
+	Variation         string                  `json:"variation,omitempty"`
+	Permalink         string                  `json:"permalink,omitempty"`
+	Sku               string                  `json:"sku,omitempty"`
+	ShortDescription  string                  `json:"short_description,omitempty"`
+	Description       string                  `json:"description,omitempty"`
+	OnSale            bool                    `json:"on_sale,omitempty"`
+	Prices            *StorePrices            `json:"prices,omitempty"`
+	PriceHtml         string                  `json:"price_html,omitempty"`
+	AverageRating     string                  `json:"average_rating,omitempty"`
+	ReviewCount       int                     `json:"review_count,omitempty"`
+	Images            []StoreImage            `json:"images,omitempty"`
+	Categories        []Category              `json:"categories,omitempty"`
+	Tags              []Tag                   `json:"tags,omitempty"`
+	Attributes        []StoreProductAttribute `json:"attributes,omitempty"`
+	Variations        []StoreProductVariation `json:"variations,omitempty"`
+	HasOptions        bool                    `json:"has_options,omitempty"`
+	IsPurchasable     bool                    `json:"is_purchasable,omitempty"`
+	IsInStock         bool                    `json:"is_in_stock,omitempty"`
+	IsOnBackorder     bool                    `json:"is_on_backorder,omitempty"`
+	LowStockRemaining *int                    `json:"low_stock_remaining,omitempty"`
+	SoldIndividually  bool                    `json:"sold_individually,omitempty"`
+	AddToCart         *StoreAddToCart         `json:"add_to_cart,omitempty"`
+	Extra             Extra                   `json:"-"`
+}
+
+// StorePrices holds Store API prices. Amounts are strings in the currency's minor unit,
+// e.g. "1250" with CurrencyMinorUnit 2 is 12.50.
+type StorePrices struct {
+	Price        string `json:"price,omitempty"`
+	RegularPrice string `json:"regular_price,omitempty"`
+	SalePrice    string `json:"sale_price,omitempty"`
+	PriceRange   *struct {
+		MinAmount string `json:"min_amount,omitempty"`
+		MaxAmount string `json:"max_amount,omitempty"`
+	} `json:"price_range,omitempty"`
+	StoreCurrency
+	Extra Extra `json:"-"`
+}
+
+// StoreCurrency describes the currency of Store API amounts
+type StoreCurrency struct {
+	CurrencyCode              string `json:"currency_code,omitempty"`
+	CurrencySymbol            string `json:"currency_symbol,omitempty"`
+	CurrencyMinorUnit         int    `json:"currency_minor_unit,omitempty"`
+	CurrencyDecimalSeparator  string `json:"currency_decimal_separator,omitempty"`
+	CurrencyThousandSeparator string `json:"currency_thousand_separator,omitempty"`
+	CurrencyPrefix            string `json:"currency_prefix,omitempty"`
+	CurrencySuffix            string `json:"currency_suffix,omitempty"`
+}
+
+type StoreImage struct {
+	ID        int64  `json:"id,omitempty"`
+	Src       string `json:"src,omitempty"`
+	Thumbnail string `json:"thumbnail,omitempty"`
+	Srcset    string `json:"srcset,omitempty"`
+	Sizes     string `json:"sizes,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Alt       string `json:"alt,omitempty"`
+	Extra     Extra  `json:"-"`
+}
+
+type StoreProductAttribute struct {
+	ID            int64  `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Taxonomy      string `json:"taxonomy,omitempty"`
+	HasVariations bool   `json:"has_variations,omitempty"`
+	Terms         []struct {
+		ID   int64  `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+		Slug string `json:"slug,omitempty"`
+	} `json:"terms,omitempty"`
+	Extra Extra `json:"-"`
+}
+
+type StoreProductVariation struct {
+	ID         int64                  `json:"id,omitempty"`
+	Attributes []StoreVariationOption `json:"attributes,omitempty"`
+	Extra      Extra                  `json:"-"`
+}
+
+// StoreVariationOption is an attribute value of a variation, also used to pick a variation
+// when adding a variable product to the cart.
+type StoreVariationOption struct {
+	Attribute string `json:"attribute,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Extra     Extra  `json:"-"`
+}
+
+type StoreAddToCart struct {
+	Text        string `json:"text,omitempty"`
+	Description string `json:"description,omitempty"`
+	Url         string `json:"url,omitempty"`
+	Minimum     int    `json:"minimum,omitempty"`
+	Maximum     int    `json:"maximum,omitempty"`
+	MultipleOf  int    `json:"multiple_of,omitempty"`
+	Extra       Extra  `json:"-"`
+}
+
+// List products visible to shoppers
+func (p *StoreProductServiceOp) List(options interface{}) ([]StoreProduct, error) {
+	resource := make([]StoreProduct, 0)
+	err := p.client.Get(storeProductsBasePath, &resource, options)
+	return resource, err
+}
+
+// Get individual product
+func (p *StoreProductServiceOp) Get(productID int64) (*StoreProduct, error) {
+	path := fmt.Sprintf("%s/%d", storeProductsBasePath, productID)
+	resource := new(StoreProduct)
+	err := p.client.Get(path, resource, nil)
+	return resource, err
+}
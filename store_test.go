@@ -0,0 +1,124 @@
+package woocommerce
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStoreClient_Session(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wp-json/wc/store/v1/cart":
+			w.Header().Set("Nonce", "nonce-1")
+			w.Header().Set("Cart-Token", "token-1")
+			w.Write([]byte(`{"items_count":0}`))
+		case "/wp-json/wc/store/v1/cart/add-item":
+			if r.Header.Get("Nonce") != "nonce-1" || r.Header.Get("Cart-Token") != "token-1" {
+				t.Errorf("session headers not sent: %v", r.Header)
+			}
+			w.Header().Set("Nonce", "nonce-2")
+			w.Write([]byte(`{"items_count":2,"items":[{"key":"abc","id":10,"quantity":2}],"billing_address":{"email":"a@b.c"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	store := NewStoreClient(srv.URL)
+	store.Client = srv.Client()
+	if _, err := store.Cart.Get(); err != nil {
+		t.Fatalf("get cart fail: %v", err)
+	}
+	cart, err := store.Cart.AddItem(CartItemRequest{ID: 10, Quantity: 2})
+	if err != nil {
+		t.Fatalf("add item fail: %v", err)
+	}
+	if cart.ItemsCount != 2 || cart.Items[0].Key != "abc" || cart.BillingAddress.Email != "a@b.c" {
+		t.Errorf("unexpected cart: %+v", cart)
+	}
+	if store.Nonce() != "nonce-2" || store.CartToken() != "token-1" {
+		t.Errorf("session not kept, nonce %q token %q", store.Nonce(), store.CartToken())
+	}
+}
+
+// newStoreTestClient returns a store client talking to handler
+func newStoreTestClient(t *testing.T, handler http.HandlerFunc, opts ...StoreOption) *StoreClient {
+	t.Helper()
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	store := NewStoreClient(srv.URL, opts...)
+	store.Client = srv.Client()
+	return store
+}
+
+func TestStoreCartServiceOp_UpdateItem(t *testing.T) {
+	var body string
+	var header http.Header
+	store := newStoreTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /wp-json/wc/store/v1/cart":
+			w.Header().Set("Nonce", "nonce-1")
+			w.Header().Set("Cart-Token", "token-1")
+			w.Write([]byte(`{"items_count":1,"items":[{"key":"abc","id":10,"quantity":1}]}`))
+		case "POST /wp-json/wc/store/v1/cart/update-item":
+			data, _ := io.ReadAll(r.Body)
+			body, header = string(data), r.Header
+			w.Write([]byte(`{"items_count":3,"items":[{"key":"abc","id":10,"quantity":3}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	if _, err := store.Cart.Get(); err != nil {
+		t.Fatal(err)
+	}
+	cart, err := store.Cart.UpdateItem("abc", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cart.ItemsCount != 3 || cart.Items[0].Quantity != 3 {
+		t.Errorf("UpdateItem() = %+v", cart)
+	}
+	if want := `{"key":"abc","quantity":3}`; body != want {
+		t.Errorf("body = %s\nwant   %s", body, want)
+	}
+	if header.Get("Nonce") != "nonce-1" || header.Get("Cart-Token") != "token-1" || header.Get("Content-Type") != "application/json" {
+		t.Errorf("headers = %v", header)
+	}
+}
+
+func TestStoreCheckoutServiceOp_Process(t *testing.T) {
+	var body string
+	var header http.Header
+	store := newStoreTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /wp-json/wc/store/v1/checkout" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body, header = string(data), r.Header
+		w.Write([]byte(`{"order_id":727,"status":"processing","order_key":"wc_order_abc","payment_method":"cod",` +
+			`"payment_result":{"payment_status":"success","redirect_url":"https://example.com/checkout/order-received/727/"}}`))
+	}, WithStoreCartToken("token-1"))
+
+	order, err := store.Checkout.Process(Checkout{
+		BillingAddress: &Billing{FirstName: "John", Email: "john@example.com"},
+		PaymentMethod:  "cod",
+		PaymentData:    []CheckoutKeyPair{{Key: "note", Value: "leave at door"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.OrderID != 727 || order.PaymentResult == nil || order.PaymentResult.PaymentStatus != "success" {
+		t.Errorf("Process() = %+v", order)
+	}
+	want := `{"billing_address":{"first_name":"John","email":"john@example.com"},"payment_method":"cod",` +
+		`"payment_data":[{"key":"note","value":"leave at door"}]}`
+	if body != want {
+		t.Errorf("body = %s\nwant   %s", body, want)
+	}
+	if header.Get("Cart-Token") != "token-1" || header.Get("Nonce") != "" || header.Get("Accept") != "application/json" {
+		t.Errorf("headers = %v", header)
+	}
+}
@@ -0,0 +1,90 @@
+package woocommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TimeLayout is the ISO8601 layout of WooCommerce timestamps, without a timezone. Fields
+// ending in _gmt are in UTC, the others in the timezone of the site.
+const TimeLayout = "2006-01-02T15:04:05"
+
+// timeLayouts are the layouts accepted when decoding, the analytics reports use a space as
+// separator and some plugins send RFC3339 or plain dates
+var timeLayouts = []string{
+	TimeLayout,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Time is a WooCommerce timestamp. Timestamps without a timezone are decoded as UTC wall
+// clock times, so a local date_created keeps the site's wall clock while date_created_gmt is
+// the actual instant. Null, empty and "never" values decode to the zero Time.
+type Time struct {
+	time.Time
+}
+
+// NewTime wraps t
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// ParseTime parses a WooCommerce timestamp such as "2024-01-31T10:00:00"
+func ParseTime(s string) (Time, error) {
+	if s == "" || s == "never" {
+		return Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Time{Time: t}, nil
+		}
+	}
+	return Time{}, fmt.Errorf("woocommerce: invalid time %q", s)
+}
+
+// String formats t with TimeLayout in t's own location, or "" for the zero Time. Use UTC
+// times for _gmt fields and filters with dates_are_gmt.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimeLayout)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("woocommerce: invalid time %s", data)
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON encodes t with TimeLayout, the zero Time as null to clear a date
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// EncodeValues encodes t with TimeLayout in query strings, see github.com/google/go-querystring
+func (t Time) EncodeValues(key string, v *url.Values) error {
+	if !t.IsZero() {
+		v.Set(key, t.String())
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package woocommerce
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	var order Order
+	err := json.Unmarshal([]byte(`{
+		"date_created": "2024-03-10T14:30:00",
+		"date_created_gmt": "2024-03-10T13:30:00",
+		"date_paid": null,
+		"date_completed": ""
+	}`), &order)
+	if err != nil {
+		t.Fatalf("unmarshal fail: %v", err)
+	}
+	want := time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC)
+	if !order.DateCreatedGmt.Equal(want) {
+		t.Errorf("date_created_gmt = %v, want %v", order.DateCreatedGmt, want)
+	}
+	if order.DateCreated.String() != "2024-03-10T14:30:00" {
+		t.Errorf("date_created = %v", order.DateCreated)
+	}
+	if order.DatePaid != nil || !order.DateCompleted.IsZero() {
+		t.Errorf("expected empty dates, got %v and %v", order.DatePaid, order.DateCompleted)
+	}
+
+	data, _ := json.Marshal(Order{DateCreatedGmt: order.DateCreatedGmt})
+	if string(data) != `{"date_created_gmt":"2024-03-10T13:30:00","_links":{}}` {
+		t.Errorf("unexpected json: %s", data)
+	}
+	if _, err := ParseTime("10/03/2024"); err == nil {
+		t.Errorf("expected invalid time error")
+	}
+}
+
+func TestTime_EncodeValues(t *testing.T) {
+	after := time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC)
+	values, err := query.Values(ProductListOptions{ModifiedAfter: NewTime(after), DatesAreGMT: true})
+	if err != nil {
+		t.Fatalf("encode fail: %v", err)
+	}
+	want := url.Values{"modified_after": {"2024-03-10T13:30:00"}, "dates_are_gmt": {"true"}}
+	if values.Encode() != want.Encode() {
+		t.Errorf("query = %s, want %s", values.Encode(), want.Encode())
+	}
+}
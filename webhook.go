@@ -18,6 +18,7 @@ type WebhookService interface {
 	Update(webhook *Webhook) (*Webhook, error)
 	Delete(webhookID int64, options interface{}) (*Webhook, error)
 	Batch(data WebhookBatchOption) (*WebhookBatchResource, error)
+	BatchAll(data WebhookBatchOption, options *BatchOption) (*BatchResult[Webhook], error)
 }
 
 // WebhookServiceOp handles communication with the webhooks related methods of WooCommerce restful api
@@ -36,17 +37,18 @@ type Webhook struct {
 	Hooks           []string `json:"hooks,omitempty"`
 	DeliveryUrl     string   `json:"delivery_url,omitempty"`
 	Secret          string   `json:"secret,omitempty"`
-	DateCreated     string   `json:"date_created,omitempty"`
-	DateCreatedGmt  string   `json:"date_created_gmt,omitempty"`
-	DateModified    string   `json:"date_modified,omitempty"`
-	DateModifiedGmt string   `json:"date_modified_gmt,omitempty"`
+	DateCreated     *Time    `json:"date_created,omitempty"`
+	DateCreatedGmt  *Time    `json:"date_created_gmt,omitempty"`
+	DateModified    *Time    `json:"date_modified,omitempty"`
+	DateModifiedGmt *Time    `json:"date_modified_gmt,omitempty"`
 	Links           Links    `json:"_links,omitempty"`
+	Extra           Extra    `json:"-"`
 }
 
 // WebhookListOption config webhook's List method request option
 type WebhookListOption struct {
 	ListOptions
-	Status string `json:"status,omitempty"`
+	Status string `json:"status,omitempty" url:"status,omitempty"`
 }
 
 // WebhookDeleteOption config webhook's Delete operation option
@@ -71,47 +73,32 @@ type WebhookBatchResource struct {
 // List return multiple webhooks
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#list-all-webhooks
 func (w *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
-	path := fmt.Sprintf("%s", webhooksBasePath)
-	resource := make([]Webhook, 0)
-	err := w.client.Get(path, &resource, options)
-	return resource, err
+	webhooks, _, err := w.resource().List(options)
+	return webhooks, err
 }
 
 // Create handle create a new webhook.
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#create-a-webhook
 func (w *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
-	path := fmt.Sprintf("%s", webhooksBasePath)
-	resource := new(Webhook)
-	err := w.client.Post(path, webhook, &resource)
-	return resource, err
+	return w.resource().Create(webhook)
 }
 
 // Get implement for retrieve and view a specific webhook
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#retrieve-a-webhook
 func (w *WebhookServiceOp) Get(webhookID int64, options interface{}) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhookID)
-	resource := new(Webhook)
-	err := w.client.Get(path, &resource, options)
-	return resource, err
+	return w.resource().Get(webhookID, options)
 }
 
 // Update method allow you to make changes to a webhook
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#update-a-webhook
 func (w *WebhookServiceOp) Update(webhook *Webhook) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhook.ID)
-	resource := new(Webhook)
-	err := w.client.Put(path, webhook, &resource)
-
-	return resource, err
+	return w.resource().Update(webhook.ID, webhook)
 }
 
 // Delete delete a webhook
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#delete-a-webhook
 func (w *WebhookServiceOp) Delete(webhookID int64, options interface{}) (*Webhook, error) {
-	path := fmt.Sprintf("%s/%d", webhooksBasePath, webhookID)
-	resource := new(Webhook)
-	err := w.client.Delete(path, options, &resource)
-	return resource, err
+	return w.resource().Delete(webhookID, options)
 }
 
 // Batch helps you to batch create, update and delete multiple webhooks
@@ -124,3 +111,12 @@ func (w *WebhookServiceOp) Batch(data WebhookBatchOption) (*WebhookBatchResource
 	err := w.client.Post(path, data, &resource)
 	return resource, err
 }
+
+// BatchAll runs a batch of any size in chunks WooCommerce accepts, with the outcome of each webhook
+func (w *WebhookServiceOp) BatchAll(data WebhookBatchOption, options *BatchOption) (*BatchResult[Webhook], error) {
+	return w.resource().Batch(BatchRequest[Webhook](data), options)
+}
+
+func (w *WebhookServiceOp) resource() *Resource[Webhook] {
+	return NewResource[Webhook](w.client, webhooksBasePath)
+}
@@ -0,0 +1,352 @@
+package woocommerce
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	defaultWebhookDedupCapacity = 10000
+)
+
+// WebhookDropReason tells why a webhook delivery was skipped
+type WebhookDropReason string
+
+const (
+	// WebhookDropDuplicate is a delivery already seen, by delivery ID or by the same resource
+	// version and payload
+	WebhookDropDuplicate WebhookDropReason = "duplicate"
+	// WebhookDropStale is a delivery for an older version of a resource than one already handled
+	WebhookDropStale WebhookDropReason = "stale"
+)
+
+// WebhookDeliveryStore remembers handled webhook deliveries, so WebhookReceiver can skip
+// duplicates and out-of-order events. Resource keys are per topic, versions are the resource's
+// date_modified_gmt, which sorts chronologically as a string, followed by "|" and a hash of
+// the payload.
+type WebhookDeliveryStore interface {
+	// SeenDelivery reports whether the delivery ID was recorded before
+	SeenDelivery(deliveryID string) (bool, error)
+	// RecordDelivery records a handled delivery ID
+	RecordDelivery(deliveryID string) error
+	// ResourceVersion returns the last handled version of the resource key
+	ResourceVersion(key string) (version string, ok bool, err error)
+	// RecordResourceVersion stores the handled version of the resource key
+	RecordResourceVersion(key, version string) error
+}
+
+// WebhookDropStats counts the deliveries dropped by a WebhookReceiver
+type WebhookDropStats struct {
+	Duplicate int64
+	Stale     int64
+}
+
+// WithWebhookDeliveryStore makes the receiver skip duplicate and stale deliveries tracked in store.
+// Skipped deliveries are acknowledged so WooCommerce doesn't report them as failed. Deliveries
+// are recorded once handled, so concurrent copies of the same delivery may both go through.
+func WithWebhookDeliveryStore(store WebhookDeliveryStore) WebhookReceiverOption {
+	return func(r *WebhookReceiver) {
+		r.store = store
+	}
+}
+
+// WithWebhookDropHandler registers a callback for every skipped delivery
+func WithWebhookDropHandler(fn func(event *WebhookEvent, reason WebhookDropReason)) WebhookReceiverOption {
+	return func(r *WebhookReceiver) {
+		r.onDrop = fn
+	}
+}
+
+// DropStats returns how many deliveries the receiver skipped so far
+func (wr *WebhookReceiver) DropStats() WebhookDropStats {
+	return WebhookDropStats{
+		Duplicate: atomic.LoadInt64(&wr.dropped.Duplicate),
+		Stale:     atomic.LoadInt64(&wr.dropped.Stale),
+	}
+}
+
+// webhookResourceVersion extracts the resource key and version of an event, ok is false for
+// payloads without an id or date_modified_gmt, e.g. deletions and actions. The key holds the
+// topic, so an order.updated sent in the same second as order.created isn't a duplicate of it.
+func webhookResourceVersion(event *WebhookEvent) (key, version string, ok bool) {
+	payload := struct {
+		ID              int64  `json:"id"`
+		DateModifiedGmt string `json:"date_modified_gmt"`
+	}{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return "", "", false
+	}
+	if payload.ID == 0 || payload.DateModifiedGmt == "" {
+		return "", "", false
+	}
+	key = event.Source + "|" + event.Topic + "|" + strconv.FormatInt(payload.ID, 10)
+	sum := sha256.Sum256(event.Payload)
+	return key, payload.DateModifiedGmt + "|" + hex.EncodeToString(sum[:16]), true
+}
+
+// checkDelivery tells whether the event must be dropped, and why. date_modified_gmt only has
+// second precision, so an event with the version of the last one handled is a duplicate only
+// when its payload is the same too: several changes within a second are all handled.
+func (wr *WebhookReceiver) checkDelivery(event *WebhookEvent) (WebhookDropReason, error) {
+	if event.DeliveryID != "" {
+		seen, err := wr.store.SeenDelivery(event.DeliveryID)
+		if err != nil || seen {
+			return WebhookDropDuplicate, err
+		}
+	}
+	key, version, ok := webhookResourceVersion(event)
+	if !ok {
+		return "", nil
+	}
+	last, found, err := wr.store.ResourceVersion(key)
+	if err != nil || !found {
+		return "", err
+	}
+	modified, _, _ := strings.Cut(version, "|")
+	lastModified, _, _ := strings.Cut(last, "|")
+	switch {
+	case version == last:
+		return WebhookDropDuplicate, nil
+	case modified < lastModified:
+		return WebhookDropStale, nil
+	}
+	return "", nil
+}
+
+// recordDelivery stores a handled event
+func (wr *WebhookReceiver) recordDelivery(event *WebhookEvent) error {
+	if event.DeliveryID != "" {
+		if err := wr.store.RecordDelivery(event.DeliveryID); err != nil {
+			return err
+		}
+	}
+	if key, version, ok := webhookResourceVersion(event); ok {
+		return wr.store.RecordResourceVersion(key, version)
+	}
+	return nil
+}
+
+// drop counts and reports a skipped event
+func (wr *WebhookReceiver) drop(event *WebhookEvent, reason WebhookDropReason) {
+	switch reason {
+	case WebhookDropDuplicate:
+		atomic.AddInt64(&wr.dropped.Duplicate, 1)
+	case WebhookDropStale:
+		atomic.AddInt64(&wr.dropped.Stale, 1)
+	}
+	wr.log.Infof("webhook %s delivery %s dropped: %s", event.Topic, event.DeliveryID, reason)
+	if wr.onDrop != nil {
+		wr.onDrop(event, reason)
+	}
+}
+
+// MemoryWebhookDeliveryStore is an in-memory WebhookDeliveryStore keeping the most recently
+// used delivery IDs and resource versions.
+type MemoryWebhookDeliveryStore struct {
+	mu         sync.Mutex
+	deliveries *lru
+	versions   *lru
+}
+
+// NewMemoryWebhookDeliveryStore returns a store remembering up to capacity delivery IDs and
+// as many resource versions, a capacity <= 0 uses 10000.
+func NewMemoryWebhookDeliveryStore(capacity int) *MemoryWebhookDeliveryStore {
+	if capacity <= 0 {
+		capacity = defaultWebhookDedupCapacity
+	}
+	return &MemoryWebhookDeliveryStore{
+		deliveries: newLRU(capacity),
+		versions:   newLRU(capacity),
+	}
+}
+
+func (m *MemoryWebhookDeliveryStore) SeenDelivery(deliveryID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.deliveries.get(deliveryID)
+	return ok, nil
+}
+
+func (m *MemoryWebhookDeliveryStore) RecordDelivery(deliveryID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deliveries.put(deliveryID, "")
+	return nil
+}
+
+func (m *MemoryWebhookDeliveryStore) ResourceVersion(key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	version, ok := m.versions.get(key)
+	return version, ok, nil
+}
+
+func (m *MemoryWebhookDeliveryStore) RecordResourceVersion(key, version string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.versions.put(key, version)
+	return nil
+}
+
+// FileWebhookDeliveryStore is a WebhookDeliveryStore persisted as JSON in a file, so
+// deduplication survives restarts. It keeps the same bounded state as the in-memory store
+// and rewrites the file on every change, fine for the delivery rate of a single shop.
+type FileWebhookDeliveryStore struct {
+	path string
+
+	mu     sync.Mutex
+	memory *MemoryWebhookDeliveryStore
+}
+
+// fileWebhookDeliveryState is the on-disk layout of FileWebhookDeliveryStore, oldest first
+type fileWebhookDeliveryState struct {
+	Deliveries []string    `json:"deliveries"`
+	Versions   [][2]string `json:"versions"`
+}
+
+// NewFileWebhookDeliveryStore opens the store at path, creating it on first write
+func NewFileWebhookDeliveryStore(path string, capacity int) (*FileWebhookDeliveryStore, error) {
+	f := &FileWebhookDeliveryStore{
+		path:   path,
+		memory: NewMemoryWebhookDeliveryStore(capacity),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state fileWebhookDeliveryState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	for _, id := range state.Deliveries {
+		f.memory.deliveries.put(id, "")
+	}
+	for _, kv := range state.Versions {
+		f.memory.versions.put(kv[0], kv[1])
+	}
+	return f, nil
+}
+
+func (f *FileWebhookDeliveryStore) SeenDelivery(deliveryID string) (bool, error) {
+	return f.memory.SeenDelivery(deliveryID)
+}
+
+func (f *FileWebhookDeliveryStore) RecordDelivery(deliveryID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.memory.RecordDelivery(deliveryID)
+	return f.save()
+}
+
+func (f *FileWebhookDeliveryStore) ResourceVersion(key string) (string, bool, error) {
+	return f.memory.ResourceVersion(key)
+}
+
+func (f *FileWebhookDeliveryStore) RecordResourceVersion(key, version string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.memory.RecordResourceVersion(key, version)
+	return f.save()
+}
+
+// save writes the state to a temporary file and renames it over the store file
+func (f *FileWebhookDeliveryStore) save() error {
+	f.memory.mu.Lock()
+	state := fileWebhookDeliveryState{
+		Deliveries: make([]string, 0, f.memory.deliveries.len()),
+		Versions:   make([][2]string, 0, f.memory.versions.len()),
+	}
+	f.memory.deliveries.each(func(key, _ string) {
+		state.Deliveries = append(state.Deliveries, key)
+	})
+	f.memory.versions.each(func(key, value string) {
+		state.Versions = append(state.Versions, [2]string{key, value})
+	})
+	f.memory.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// lru is a fixed size least recently used string map, not safe for concurrent use
+type lru struct {
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value string
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (l *lru) get(key string) (string, bool) {
+	e, ok := l.items[key]
+	if !ok {
+		return "", false
+	}
+	l.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+func (l *lru) put(key, value string) {
+	if e, ok := l.items[key]; ok {
+		e.Value.(*lruEntry).value = value
+		l.order.MoveToFront(e)
+		return
+	}
+	l.items[key] = l.order.PushFront(&lruEntry{key: key, value: value})
+	if l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) len() int {
+	return l.order.Len()
+}
+
+// each calls fn from the least to the most recently used entry
+func (l *lru) each(fn func(key, value string)) {
+	for e := l.order.Back(); e != nil; e = e.Prev() {
+		entry := e.Value.(*lruEntry)
+		fn(entry.key, entry.value)
+	}
+}
@@ -0,0 +1,198 @@
+package woocommerce
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// Webhook topics, see Webhook.Topic
+// https://woocommerce.github.io/woocommerce-rest-api-docs/#topics
+const (
+	WebhookTopicOrderCreated    = "order.created"
+	WebhookTopicOrderUpdated    = "order.updated"
+	WebhookTopicOrderDeleted    = "order.deleted"
+	WebhookTopicOrderRestored   = "order.restored"
+	WebhookTopicProductCreated  = "product.created"
+	WebhookTopicProductUpdated  = "product.updated"
+	WebhookTopicProductDeleted  = "product.deleted"
+	WebhookTopicProductRestored = "product.restored"
+	WebhookTopicCustomerCreated = "customer.created"
+	WebhookTopicCustomerUpdated = "customer.updated"
+	WebhookTopicCustomerDeleted = "customer.deleted"
+	WebhookTopicCouponCreated   = "coupon.created"
+	WebhookTopicCouponUpdated   = "coupon.updated"
+	WebhookTopicCouponDeleted   = "coupon.deleted"
+	WebhookTopicCouponRestored  = "coupon.restored"
+	webhookTopicActionPrefix    = "action."
+)
+
+// WebhookPanicError is returned by WebhookDispatcher when a handler panics
+type WebhookPanicError struct {
+	Topic string
+	Value interface{}
+	Stack []byte
+}
+
+func (e WebhookPanicError) Error() string {
+	return fmt.Sprintf("woocommerce: webhook handler for %s panicked: %v", e.Topic, e.Value)
+}
+
+// WebhookDispatcher is a WebhookHandler routing events to typed per-topic handlers. Use it
+// with NewWebhookReceiver; a failing or panicking handler makes the receiver answer with a
+// server error, so WooCommerce records the delivery as failed. Deliveries of topics without
+// a handler go to the OnUnknown handler, or are acknowledged when there is none.
+type WebhookDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandlerFunc
+	unknown  WebhookHandlerFunc
+}
+
+// NewWebhookDispatcher returns a dispatcher without handlers
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		handlers: make(map[string]WebhookHandlerFunc),
+	}
+}
+
+// On registers the handler of a topic, replacing any previous one
+func (d *WebhookDispatcher) On(topic string, handler WebhookHandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[topic] = handler
+}
+
+// OnUnknown registers the handler of topics without a dedicated handler
+func (d *WebhookDispatcher) OnUnknown(handler WebhookHandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unknown = handler
+}
+
+// OnAction registers the handler of the "action.<action>" topic, e.g. "woocommerce_add_to_cart"
+func (d *WebhookDispatcher) OnAction(action string, fn func(ctx context.Context, action *WebhookAction) error) {
+	d.On(webhookTopicActionPrefix+action, func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Action)
+	})
+}
+
+// OnOrderCreated registers the order.created handler
+func (d *WebhookDispatcher) OnOrderCreated(fn func(ctx context.Context, order *Order) error) {
+	d.On(WebhookTopicOrderCreated, orderWebhookHandler(fn))
+}
+
+// OnOrderUpdated registers the order.updated handler
+func (d *WebhookDispatcher) OnOrderUpdated(fn func(ctx context.Context, order *Order) error) {
+	d.On(WebhookTopicOrderUpdated, orderWebhookHandler(fn))
+}
+
+// OnOrderDeleted registers the order.deleted handler, the order only has its ID set
+func (d *WebhookDispatcher) OnOrderDeleted(fn func(ctx context.Context, order *Order) error) {
+	d.On(WebhookTopicOrderDeleted, orderWebhookHandler(fn))
+}
+
+// OnOrderRestored registers the order.restored handler
+func (d *WebhookDispatcher) OnOrderRestored(fn func(ctx context.Context, order *Order) error) {
+	d.On(WebhookTopicOrderRestored, orderWebhookHandler(fn))
+}
+
+// OnProductCreated registers the product.created handler
+func (d *WebhookDispatcher) OnProductCreated(fn func(ctx context.Context, product *Product) error) {
+	d.On(WebhookTopicProductCreated, productWebhookHandler(fn))
+}
+
+// OnProductUpdated registers the product.updated handler
+func (d *WebhookDispatcher) OnProductUpdated(fn func(ctx context.Context, product *Product) error) {
+	d.On(WebhookTopicProductUpdated, productWebhookHandler(fn))
+}
+
+// OnProductDeleted registers the product.deleted handler, the product only has its ID set
+func (d *WebhookDispatcher) OnProductDeleted(fn func(ctx context.Context, product *Product) error) {
+	d.On(WebhookTopicProductDeleted, productWebhookHandler(fn))
+}
+
+// OnProductRestored registers the product.restored handler
+func (d *WebhookDispatcher) OnProductRestored(fn func(ctx context.Context, product *Product) error) {
+	d.On(WebhookTopicProductRestored, productWebhookHandler(fn))
+}
+
+// OnCustomerCreated registers the customer.created handler
+func (d *WebhookDispatcher) OnCustomerCreated(fn func(ctx context.Context, customer *Customer) error) {
+	d.On(WebhookTopicCustomerCreated, customerWebhookHandler(fn))
+}
+
+// OnCustomerUpdated registers the customer.updated handler
+func (d *WebhookDispatcher) OnCustomerUpdated(fn func(ctx context.Context, customer *Customer) error) {
+	d.On(WebhookTopicCustomerUpdated, customerWebhookHandler(fn))
+}
+
+// OnCustomerDeleted registers the customer.deleted handler, the customer only has its ID set
+func (d *WebhookDispatcher) OnCustomerDeleted(fn func(ctx context.Context, customer *Customer) error) {
+	d.On(WebhookTopicCustomerDeleted, customerWebhookHandler(fn))
+}
+
+// OnCouponCreated registers the coupon.created handler
+func (d *WebhookDispatcher) OnCouponCreated(fn func(ctx context.Context, coupon *Coupon) error) {
+	d.On(WebhookTopicCouponCreated, couponWebhookHandler(fn))
+}
+
+// OnCouponUpdated registers the coupon.updated handler
+func (d *WebhookDispatcher) OnCouponUpdated(fn func(ctx context.Context, coupon *Coupon) error) {
+	d.On(WebhookTopicCouponUpdated, couponWebhookHandler(fn))
+}
+
+// OnCouponDeleted registers the coupon.deleted handler, the coupon only has its ID set
+func (d *WebhookDispatcher) OnCouponDeleted(fn func(ctx context.Context, coupon *Coupon) error) {
+	d.On(WebhookTopicCouponDeleted, couponWebhookHandler(fn))
+}
+
+// OnCouponRestored registers the coupon.restored handler
+func (d *WebhookDispatcher) OnCouponRestored(fn func(ctx context.Context, coupon *Coupon) error) {
+	d.On(WebhookTopicCouponRestored, couponWebhookHandler(fn))
+}
+
+// HandleWebhook implements WebhookHandler
+func (d *WebhookDispatcher) HandleWebhook(ctx context.Context, event *WebhookEvent) (err error) {
+	d.mu.RLock()
+	handler, ok := d.handlers[event.Topic]
+	if !ok {
+		handler = d.unknown
+	}
+	d.mu.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			err = WebhookPanicError{Topic: event.Topic, Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return handler(ctx, event)
+}
+
+func orderWebhookHandler(fn func(ctx context.Context, order *Order) error) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Order)
+	}
+}
+
+func productWebhookHandler(fn func(ctx context.Context, product *Product) error) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Product)
+	}
+}
+
+func customerWebhookHandler(fn func(ctx context.Context, customer *Customer) error) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Customer)
+	}
+}
+
+func couponWebhookHandler(fn func(ctx context.Context, coupon *Coupon) error) WebhookHandlerFunc {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		return fn(ctx, event.Coupon)
+	}
+}
@@ -0,0 +1,235 @@
+package woocommerce
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWebhookMonitorInterval = 5 * time.Minute
+	defaultReactivateBackoff      = time.Minute
+	defaultReactivateMaxBackoff   = 6 * time.Hour
+	webhookSecretSize             = 32
+)
+
+// WebhookAlert reports an unhealthy webhook found by WebhookMonitor. When reactivation is
+// enabled, Reactivated tells whether it was set back to active in this check, Err holds the
+// reactivation failure and NextAttempt when the monitor tries again.
+type WebhookAlert struct {
+	Webhook     Webhook
+	Reactivated bool
+	Attempts    int
+	NextAttempt time.Time
+	Err         error
+}
+
+// WebhookMonitor periodically lists a shop's webhooks and reports the ones WooCommerce paused
+// or disabled, typically after repeated delivery failures, optionally reactivating them with
+// an exponential backoff between attempts.
+type WebhookMonitor struct {
+	svc        WebhookService
+	interval   time.Duration
+	onAlert    func(alert WebhookAlert)
+	reactivate bool
+	backoff    time.Duration
+	maxBackoff time.Duration
+	log        LeveledLoggerInterface
+	now        func() time.Time
+	// keys of the webhooks meant to be paused or disabled, see WithMonitorSpecs
+	inactive map[string]bool
+
+	mu       sync.Mutex
+	attempts map[int64]*webhookReactivation
+}
+
+type webhookReactivation struct {
+	attempts int
+	next     time.Time
+}
+
+type WebhookMonitorOption func(m *WebhookMonitor)
+
+// WithMonitorInterval sets how often Run checks the webhooks, defaults to 5 minutes
+func WithMonitorInterval(interval time.Duration) WebhookMonitorOption {
+	return func(m *WebhookMonitor) {
+		m.interval = interval
+	}
+}
+
+// WithMonitorAlert sets the callback receiving every unhealthy webhook
+func WithMonitorAlert(fn func(alert WebhookAlert)) WebhookMonitorOption {
+	return func(m *WebhookMonitor) {
+		m.onAlert = fn
+	}
+}
+
+// WithMonitorReactivation sets unhealthy webhooks back to active, waiting backoff after the
+// first attempt and doubling it up to maxBackoff while the webhook keeps being disabled.
+// Zero values use 1 minute and 6 hours.
+func WithMonitorReactivation(backoff, maxBackoff time.Duration) WebhookMonitorOption {
+	return func(m *WebhookMonitor) {
+		m.reactivate = true
+		if backoff > 0 {
+			m.backoff = backoff
+		}
+		if maxBackoff > 0 {
+			m.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithMonitorSpecs sets the specs the webhooks are reconciled with, see ReconcileWebhooks. The
+// webhooks whose spec status is paused or disabled are neither reported nor reactivated.
+func WithMonitorSpecs(specs []WebhookSpec) WebhookMonitorOption {
+	return func(m *WebhookMonitor) {
+		m.inactive = make(map[string]bool)
+		for _, spec := range specs {
+			if spec.Status == WebhookStatusPaused || spec.Status == WebhookStatusDisabled {
+				m.inactive[webhookKey(spec.Topic, spec.DeliveryUrl)] = true
+			}
+		}
+	}
+}
+
+// WithMonitorLog log config option
+func WithMonitorLog(logger LeveledLoggerInterface) WebhookMonitorOption {
+	return func(m *WebhookMonitor) {
+		m.log = logger
+	}
+}
+
+// NewWebhookMonitor returns a monitor of the webhooks of svc
+func NewWebhookMonitor(svc WebhookService, opts ...WebhookMonitorOption) *WebhookMonitor {
+	m := &WebhookMonitor{
+		svc:        svc,
+		interval:   defaultWebhookMonitorInterval,
+		backoff:    defaultReactivateBackoff,
+		maxBackoff: defaultReactivateMaxBackoff,
+		log:        &LeveledLogger{},
+		now:        time.Now,
+		attempts:   make(map[int64]*webhookReactivation),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Run checks the webhooks every interval until ctx is done. Failing checks are logged and
+// retried at the next interval.
+func (m *WebhookMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Check(); err != nil {
+			m.log.Errorf("webhook monitor check failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check lists the webhooks once, reports and, if enabled, reactivates the unhealthy ones.
+// The alert callback and the reactivations run without holding the monitor's lock.
+func (m *WebhookMonitor) Check() ([]WebhookAlert, error) {
+	webhooks, err := ListAllWebhooks(m.svc)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, attempts := m.plan(webhooks)
+	for i := range alerts {
+		if attempts[i] {
+			m.reactivateWebhook(&alerts[i])
+		}
+		if m.onAlert != nil {
+			m.onAlert(alerts[i])
+		}
+	}
+	return alerts, nil
+}
+
+// plan returns the alerts of the unhealthy webhooks and whether to attempt their reactivation
+// now, counting the attempts
+func (m *WebhookMonitor) plan(webhooks []Webhook) ([]WebhookAlert, []bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alerts := make([]WebhookAlert, 0)
+	attempts := make([]bool, 0)
+	seen := make(map[int64]bool, len(webhooks))
+	now := m.now()
+	for _, webhook := range webhooks {
+		seen[webhook.ID] = true
+		if webhook.Status != WebhookStatusDisabled && webhook.Status != WebhookStatusPaused ||
+			m.inactive[webhookKey(webhook.Topic, webhook.DeliveryUrl)] {
+			delete(m.attempts, webhook.ID)
+			continue
+		}
+
+		alert := WebhookAlert{Webhook: webhook}
+		attempt := false
+		if m.reactivate {
+			state, ok := m.attempts[webhook.ID]
+			if !ok {
+				state = new(webhookReactivation)
+				m.attempts[webhook.ID] = state
+			}
+			if attempt = !now.Before(state.next); attempt {
+				state.attempts++
+				wait := m.backoff << (state.attempts - 1)
+				if wait > m.maxBackoff || wait <= 0 {
+					wait = m.maxBackoff
+				}
+				state.next = now.Add(wait)
+			}
+			alert.Attempts = state.attempts
+			alert.NextAttempt = state.next
+		}
+		alerts = append(alerts, alert)
+		attempts = append(attempts, attempt)
+	}
+	for id := range m.attempts {
+		if !seen[id] {
+			delete(m.attempts, id)
+		}
+	}
+	return alerts, attempts
+}
+
+// reactivateWebhook sets the alert's webhook back to active
+func (m *WebhookMonitor) reactivateWebhook(alert *WebhookAlert) {
+	_, err := m.svc.Update(&Webhook{ID: alert.Webhook.ID, Status: WebhookStatusActive})
+	alert.Reactivated = err == nil
+	alert.Err = err
+	m.log.Infof("webhook %d %s reactivation attempt %d: %v", alert.Webhook.ID, alert.Webhook.Topic, alert.Attempts, err)
+}
+
+// GenerateWebhookSecret returns a random secret suitable for Webhook.Secret
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RotateWebhookSecret replaces the secret of a webhook, generating one when secret is empty.
+// Deliveries already queued may still be signed with the old secret, so keep accepting it for
+// a while, see WithWebhookSecrets.
+func RotateWebhookSecret(svc WebhookService, webhookID int64, secret string) (*Webhook, error) {
+	if secret == "" {
+		generated, err := GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+	return svc.Update(&Webhook{ID: webhookID, Secret: secret})
+}
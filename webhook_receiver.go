@@ -0,0 +1,293 @@
+package woocommerce
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Headers WooCommerce sets on webhook deliveries
+const (
+	WebhookSourceHeader     = "X-WC-Webhook-Source"
+	WebhookTopicHeader      = "X-WC-Webhook-Topic"
+	WebhookResourceHeader   = "X-WC-Webhook-Resource"
+	WebhookEventHeader      = "X-WC-Webhook-Event"
+	WebhookSignatureHeader  = "X-WC-Webhook-Signature"
+	WebhookIDHeader         = "X-WC-Webhook-ID"
+	WebhookDeliveryIDHeader = "X-WC-Webhook-Delivery-ID"
+
+	defaultWebhookMaxBodySize = 10 << 20
+)
+
+var (
+	// ErrWebhookSignature is returned when a delivery is unsigned or its signature doesn't match
+	ErrWebhookSignature = errors.New("woocommerce: invalid webhook signature")
+)
+
+// WebhookEvent is a decoded webhook delivery. Depending on Resource one of Order, Product,
+// Customer, Coupon or Action is set; Payload always holds the raw body.
+type WebhookEvent struct {
+	Topic      string
+	Resource   string
+	Event      string
+	WebhookID  int64
+	DeliveryID string
+	Source     string
+	Payload    []byte
+
+	Order    *Order
+	Product  *Product
+	Customer *Customer
+	Coupon   *Coupon
+	Action   *WebhookAction
+}
+
+// WebhookAction is the payload of "action.*" topics, fired for WordPress actions
+type WebhookAction struct {
+	Action string          `json:"action"`
+	Arg    json.RawMessage `json:"arg"`
+}
+
+// WebhookHandler handles verified webhook deliveries. Returning an error answers the
+// delivery with a server error, so WooCommerce records it as failed.
+type WebhookHandler interface {
+	HandleWebhook(ctx context.Context, event *WebhookEvent) error
+}
+
+// WebhookHandlerFunc is an adapter to use an ordinary function as WebhookHandler
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// HandleWebhook calls f(ctx, event)
+func (f WebhookHandlerFunc) HandleWebhook(ctx context.Context, event *WebhookEvent) error {
+	return f(ctx, event)
+}
+
+// WebhookReceiver is an http.Handler receiving WooCommerce webhook deliveries. It answers the
+// ping WooCommerce sends when a webhook is saved, verifies the signature of every delivery
+// against the webhook secret and passes the decoded event to its WebhookHandler.
+type WebhookReceiver struct {
+	secrets     []string
+	handler     WebhookHandler
+	log         LeveledLoggerInterface
+	maxBodySize int64
+
+	// deduplication, see WithWebhookDeliveryStore
+	store   WebhookDeliveryStore
+	onDrop  func(event *WebhookEvent, reason WebhookDropReason)
+	dropped WebhookDropStats
+}
+
+type WebhookReceiverOption func(r *WebhookReceiver)
+
+// WithWebhookLog log config option
+func WithWebhookLog(logger LeveledLoggerInterface) WebhookReceiverOption {
+	return func(r *WebhookReceiver) {
+		r.log = logger
+	}
+}
+
+// WithWebhookMaxBodySize limits the size of accepted deliveries, defaults to 10MB
+func WithWebhookMaxBodySize(size int64) WebhookReceiverOption {
+	return func(r *WebhookReceiver) {
+		r.maxBodySize = size
+	}
+}
+
+// WithWebhookSecrets accepts deliveries signed with additional secrets, e.g. the previous secret
+// while rotating it with RotateWebhookSecret
+func WithWebhookSecrets(secrets ...string) WebhookReceiverOption {
+	return func(r *WebhookReceiver) {
+		r.secrets = append(r.secrets, secrets...)
+	}
+}
+
+// NewWebhookReceiver returns a receiver for deliveries signed with secret, the Webhook.Secret
+// of the registered webhook.
+func NewWebhookReceiver(secret string, handler WebhookHandler, opts ...WebhookReceiverOption) *WebhookReceiver {
+	r := &WebhookReceiver{
+		secrets:     []string{secret},
+		handler:     handler,
+		log:         &LeveledLogger{},
+		maxBodySize: defaultWebhookMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// ServeHTTP implements http.Handler
+func (wr *WebhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, wr.maxBodySize+1))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > wr.maxBodySize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signature := r.Header.Get(WebhookSignatureHeader)
+	if signature == "" && isWebhookPing(body) {
+		wr.log.Debugf("webhook ping received: %s", body)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !wr.verify(body, signature) {
+		wr.log.Warnf("webhook rejected: %v", ErrWebhookSignature)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseWebhookEvent(r.Header, body)
+	if err != nil {
+		wr.log.Warnf("webhook rejected: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	if wr.store != nil {
+		reason, err := wr.checkDelivery(event)
+		if err != nil {
+			wr.log.Errorf("webhook %s delivery %s check failed: %v", event.Topic, event.DeliveryID, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if reason != "" {
+			wr.drop(event, reason)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if wr.handler != nil {
+		if err := wr.handler.HandleWebhook(r.Context(), event); err != nil {
+			wr.log.Errorf("webhook %s delivery %s failed: %v", event.Topic, event.DeliveryID, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if wr.store != nil {
+		if err := wr.recordDelivery(event); err != nil {
+			wr.log.Errorf("webhook %s delivery %s not recorded: %v", event.Topic, event.DeliveryID, err)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify reports whether the delivery is signed with one of the receiver's secrets
+func (wr *WebhookReceiver) verify(body []byte, signature string) bool {
+	for _, secret := range wr.secrets {
+		if VerifyWebhookSignature(body, signature, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyWebhookSignature reports whether signature is the base64 encoded HMAC-SHA256 of body
+// keyed with secret, as sent in the X-WC-Webhook-Signature header.
+func VerifyWebhookSignature(body []byte, signature, secret string) bool {
+	if signature == "" {
+		return false
+	}
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// SignWebhookPayload returns the signature WooCommerce sends for body, useful to test handlers
+func SignWebhookPayload(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// isWebhookPing reports whether body is the "webhook_id=<id>" form post WooCommerce sends to
+// test a delivery url.
+func isWebhookPing(body []byte) bool {
+	values, err := url.ParseQuery(string(body))
+	if err != nil || len(values) != 1 {
+		return false
+	}
+	_, err = strconv.ParseInt(values.Get("webhook_id"), 10, 64)
+	return err == nil
+}
+
+// ParseWebhookEvent decodes a delivery based on its X-WC-Webhook-* headers. It doesn't verify
+// the signature, see VerifyWebhookSignature.
+func ParseWebhookEvent(header http.Header, body []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{
+		Topic:      header.Get(WebhookTopicHeader),
+		Resource:   header.Get(WebhookResourceHeader),
+		Event:      header.Get(WebhookEventHeader),
+		DeliveryID: header.Get(WebhookDeliveryIDHeader),
+		Source:     header.Get(WebhookSourceHeader),
+		Payload:    body,
+	}
+	if event.Topic == "" {
+		return nil, fmt.Errorf("woocommerce: webhook delivery without %s header", WebhookTopicHeader)
+	}
+	if id := header.Get(WebhookIDHeader); id != "" {
+		webhookID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("woocommerce: invalid %s header: %v", WebhookIDHeader, err)
+		}
+		event.WebhookID = webhookID
+	}
+
+	resource, action, _ := strings.Cut(event.Topic, ".")
+	if event.Resource == "" {
+		event.Resource = resource
+	}
+	if event.Event == "" {
+		event.Event = action
+	}
+
+	var v interface{}
+	switch event.Resource {
+	case "order":
+		event.Order = new(Order)
+		v = event.Order
+	case "product":
+		event.Product = new(Product)
+		v = event.Product
+	case "customer":
+		event.Customer = new(Customer)
+		v = event.Customer
+	case "coupon":
+		event.Coupon = new(Coupon)
+		v = event.Coupon
+	case "action":
+		event.Action = new(WebhookAction)
+		v = event.Action
+	default:
+		// unknown resources, e.g. from plugins, are left in Payload
+		return event, nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("woocommerce: decode %s webhook: %w", event.Topic, err)
+	}
+	return event, nil
+}
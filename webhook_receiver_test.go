@@ -0,0 +1,199 @@
+package woocommerce
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const webhookTestSecret = "wc-secret"
+
+func newWebhookDelivery(topic, body, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(WebhookTopicHeader, topic)
+	req.Header.Set(WebhookIDHeader, "7")
+	req.Header.Set(WebhookDeliveryIDHeader, "delivery-1")
+	if signature != "" {
+		req.Header.Set(WebhookSignatureHeader, signature)
+	}
+	return req
+}
+
+func TestWebhookReceiver_ServeHTTP(t *testing.T) {
+	var got *WebhookEvent
+	receiver := NewWebhookReceiver(webhookTestSecret, WebhookHandlerFunc(func(ctx context.Context, event *WebhookEvent) error {
+		got = event
+		return nil
+	}))
+	orderBody := `{"id":42,"status":"processing","billing":{"email":"a@b.c"}}`
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"ping", httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader("webhook_id=7")), http.StatusOK},
+		{"unsigned", newWebhookDelivery("order.created", orderBody, ""), http.StatusUnauthorized},
+		{"tampered", newWebhookDelivery("order.created", orderBody, SignWebhookPayload([]byte(`{"id":43}`), webhookTestSecret)), http.StatusUnauthorized},
+		{"wrong secret", newWebhookDelivery("order.created", orderBody, SignWebhookPayload([]byte(orderBody), "other")), http.StatusUnauthorized},
+		{"signed", newWebhookDelivery("order.created", orderBody, SignWebhookPayload([]byte(orderBody), webhookTestSecret)), http.StatusOK},
+		{"get", httptest.NewRequest(http.MethodGet, "/webhooks", nil), http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			receiver.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.code {
+				t.Errorf("status = %d, want %d", rec.Code, tt.code)
+			}
+		})
+	}
+
+	if got == nil || got.Order == nil {
+		t.Fatalf("signed delivery not handled: %+v", got)
+	}
+	if got.Order.ID != 42 || got.Resource != "order" || got.Event != "created" || got.WebhookID != 7 || got.DeliveryID != "delivery-1" {
+		t.Errorf("unexpected event: %+v", got)
+	}
+}
+
+func TestParseWebhookEvent_Action(t *testing.T) {
+	header := http.Header{}
+	header.Set(WebhookTopicHeader, "action.woocommerce_add_to_cart")
+	event, err := ParseWebhookEvent(header, []byte(`{"action":"woocommerce_add_to_cart","arg":"abc"}`))
+	if err != nil {
+		t.Fatalf("parse fail: %v", err)
+	}
+	if event.Action == nil || event.Action.Action != "woocommerce_add_to_cart" || string(event.Action.Arg) != `"abc"` {
+		t.Errorf("unexpected action: %+v", event.Action)
+	}
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	dispatcher := NewWebhookDispatcher()
+	var created, unknown int64
+	dispatcher.OnOrderCreated(func(ctx context.Context, order *Order) error {
+		created = order.ID
+		return nil
+	})
+	dispatcher.OnProductDeleted(func(ctx context.Context, product *Product) error {
+		panic("boom")
+	})
+	dispatcher.OnUnknown(func(ctx context.Context, event *WebhookEvent) error {
+		unknown = event.WebhookID
+		return nil
+	})
+	receiver := NewWebhookReceiver(webhookTestSecret, dispatcher)
+
+	deliver := func(topic, body string) int {
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, newWebhookDelivery(topic, body, SignWebhookPayload([]byte(body), webhookTestSecret)))
+		return rec.Code
+	}
+
+	if code := deliver(WebhookTopicOrderCreated, `{"id":42}`); code != http.StatusOK || created != 42 {
+		t.Errorf("order.created: status %d, handled order %d", code, created)
+	}
+	if code := deliver(WebhookTopicProductDeleted, `{"id":9}`); code != http.StatusInternalServerError {
+		t.Errorf("panicking handler: status %d, want 500", code)
+	}
+	if code := deliver("subscription.created", `{"id":3}`); code != http.StatusOK || unknown != 7 {
+		t.Errorf("unknown topic: status %d, fallback webhook %d", code, unknown)
+	}
+}
+
+func TestWebhookReceiver_Deduplication(t *testing.T) {
+	store, err := NewFileWebhookDeliveryStore(filepath.Join(t.TempDir(), "deliveries.json"), 0)
+	if err != nil {
+		t.Fatalf("open store fail: %v", err)
+	}
+	var handled []string
+	var dropped []WebhookDropReason
+	receiver := NewWebhookReceiver(webhookTestSecret,
+		WebhookHandlerFunc(func(ctx context.Context, event *WebhookEvent) error {
+			handled = append(handled, event.Order.DateModifiedGmt.String())
+			return nil
+		}),
+		WithWebhookDeliveryStore(store),
+		WithWebhookDropHandler(func(event *WebhookEvent, reason WebhookDropReason) {
+			dropped = append(dropped, reason)
+		}))
+
+	deliver := func(deliveryID, modified string) {
+		body := `{"id":42,"date_modified_gmt":"` + modified + `"}`
+		req := newWebhookDelivery(WebhookTopicOrderUpdated, body, SignWebhookPayload([]byte(body), webhookTestSecret))
+		req.Header.Set(WebhookDeliveryIDHeader, deliveryID)
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("delivery %s: status %d", deliveryID, rec.Code)
+		}
+	}
+	deliver("d1", "2024-05-01T10:00:00")
+	deliver("d1", "2024-05-01T10:00:00") // retried delivery
+	deliver("d2", "2024-05-01T10:00:00") // duplicate event for the same change
+	deliver("d3", "2024-05-01T09:00:00") // out of order
+	deliver("d4", "2024-05-01T11:00:00")
+
+	if len(handled) != 2 {
+		t.Errorf("handled %v, want 2 deliveries", handled)
+	}
+	stats := receiver.DropStats()
+	if stats.Duplicate != 2 || stats.Stale != 1 || len(dropped) != 3 {
+		t.Errorf("unexpected drops: %+v %v", stats, dropped)
+	}
+
+	reopened, err := NewFileWebhookDeliveryStore(store.path, 0)
+	if err != nil {
+		t.Fatalf("reopen store fail: %v", err)
+	}
+	if seen, _ := reopened.SeenDelivery("d4"); !seen {
+		t.Errorf("delivery d4 not persisted")
+	}
+}
+
+func TestWebhookReceiver_DeduplicationSameSecond(t *testing.T) {
+	var handled []string
+	receiver := NewWebhookReceiver(webhookTestSecret,
+		WebhookHandlerFunc(func(ctx context.Context, event *WebhookEvent) error {
+			handled = append(handled, event.Topic+" "+string(event.Order.Status))
+			return nil
+		}),
+		WithWebhookDeliveryStore(NewMemoryWebhookDeliveryStore(0)))
+
+	deliver := func(topic, deliveryID, status string) {
+		body := `{"id":42,"status":"` + status + `","date_modified_gmt":"2024-05-01T10:00:00"}`
+		req := newWebhookDelivery(topic, body, SignWebhookPayload([]byte(body), webhookTestSecret))
+		req.Header.Set(WebhookDeliveryIDHeader, deliveryID)
+		receiver.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	deliver(WebhookTopicOrderCreated, "d1", "pending")
+	deliver(WebhookTopicOrderUpdated, "d2", "pending")
+	deliver(WebhookTopicOrderUpdated, "d3", "processing") // paid within the same second
+	deliver(WebhookTopicOrderUpdated, "d4", "processing") // same change sent again
+
+	want := []string{"order.created pending", "order.updated pending", "order.updated processing"}
+	if strings.Join(handled, ",") != strings.Join(want, ",") {
+		t.Errorf("handled %v, want %v", handled, want)
+	}
+	if stats := receiver.DropStats(); stats.Duplicate != 1 || stats.Stale != 0 {
+		t.Errorf("unexpected drops: %+v", stats)
+	}
+}
+
+func TestMemoryWebhookDeliveryStore_Eviction(t *testing.T) {
+	store := NewMemoryWebhookDeliveryStore(2)
+	store.RecordDelivery("a")
+	store.RecordDelivery("b")
+	store.SeenDelivery("a")
+	store.RecordDelivery("c")
+	if seen, _ := store.SeenDelivery("b"); seen {
+		t.Errorf("least recently used delivery not evicted")
+	}
+	if seen, _ := store.SeenDelivery("a"); !seen {
+		t.Errorf("recently used delivery evicted")
+	}
+}
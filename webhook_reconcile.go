@@ -0,0 +1,212 @@
+package woocommerce
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	WebhookStatusActive   = "active"
+	WebhookStatusPaused   = "paused"
+	WebhookStatusDisabled = "disabled"
+)
+
+// WebhookSpec describes a webhook that should exist on a shop, identified by its topic and
+// delivery url. An empty Status means active, an empty Secret leaves the current one as is.
+type WebhookSpec struct {
+	Name        string
+	Topic       string
+	DeliveryUrl string
+	Secret      string
+	Status      string
+}
+
+// WebhookReconcileOption configures ReconcileWebhooks. Prune deletes the webhooks not matching
+// any spec, DryRun only computes the plan.
+type WebhookReconcileOption struct {
+	Prune  bool
+	DryRun bool
+}
+
+// WebhookChange is a webhook to update, Fields lists the properties that differ
+type WebhookChange struct {
+	Current Webhook
+	Desired Webhook
+	Fields  []string
+}
+
+// WebhookPlan lists the operations converging a shop's webhooks to their specs
+type WebhookPlan struct {
+	Create []Webhook
+	Update []WebhookChange
+	Delete []Webhook
+}
+
+// Empty reports whether the webhooks already match their specs
+func (p *WebhookPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String renders the plan for a dry-run output
+func (p *WebhookPlan) String() string {
+	if p.Empty() {
+		return "webhooks up to date\n"
+	}
+	var b strings.Builder
+	for _, w := range p.Create {
+		fmt.Fprintf(&b, "+ create %s -> %s (%s)\n", w.Topic, w.DeliveryUrl, w.Status)
+	}
+	for _, c := range p.Update {
+		fmt.Fprintf(&b, "~ update #%d %s -> %s: %s\n", c.Current.ID, c.Current.Topic, c.Current.DeliveryUrl, strings.Join(c.Fields, ", "))
+	}
+	for _, w := range p.Delete {
+		fmt.Fprintf(&b, "- delete #%d %s -> %s (%s)\n", w.ID, w.Topic, w.DeliveryUrl, w.Status)
+	}
+	return b.String()
+}
+
+// PlanWebhooks diffs the current webhooks against the specs. Extra webhooks with the topic and
+// delivery url of a spec are duplicates and always deleted, the active one with the lowest ID
+// is kept.
+func PlanWebhooks(current []Webhook, specs []WebhookSpec, prune bool) (*WebhookPlan, error) {
+	desired := make(map[string]Webhook, len(specs))
+	keys := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		if spec.Topic == "" || spec.DeliveryUrl == "" {
+			return nil, errors.New("woocommerce: webhook spec needs a topic and a delivery url")
+		}
+		key := webhookKey(spec.Topic, spec.DeliveryUrl)
+		if _, ok := desired[key]; ok {
+			return nil, fmt.Errorf("woocommerce: duplicate webhook spec for %s", key)
+		}
+		desired[key] = spec.webhook()
+		keys = append(keys, key)
+	}
+
+	existing := make(map[string][]Webhook)
+	for _, w := range current {
+		key := webhookKey(w.Topic, w.DeliveryUrl)
+		existing[key] = append(existing[key], w)
+	}
+
+	plan := new(WebhookPlan)
+	for _, key := range keys {
+		want := desired[key]
+		matches := existing[key]
+		delete(existing, key)
+		if len(matches) == 0 {
+			plan.Create = append(plan.Create, want)
+			continue
+		}
+
+		sort.Slice(matches, func(i, j int) bool {
+			iActive, jActive := matches[i].Status == WebhookStatusActive, matches[j].Status == WebhookStatusActive
+			if iActive != jActive {
+				return iActive
+			}
+			return matches[i].ID < matches[j].ID
+		})
+		keep := matches[0]
+		plan.Delete = append(plan.Delete, matches[1:]...)
+
+		if fields := webhookDiff(keep, want); len(fields) > 0 {
+			want.ID = keep.ID
+			plan.Update = append(plan.Update, WebhookChange{Current: keep, Desired: want, Fields: fields})
+		}
+	}
+
+	if prune {
+		for _, w := range current {
+			if _, ok := existing[webhookKey(w.Topic, w.DeliveryUrl)]; ok {
+				plan.Delete = append(plan.Delete, w)
+			}
+		}
+	}
+	sort.Slice(plan.Delete, func(i, j int) bool { return plan.Delete[i].ID < plan.Delete[j].ID })
+	return plan, nil
+}
+
+// ReconcileWebhooks converges the shop's webhooks to the specs through WebhookService.BatchAll and
+// returns the applied plan, or only computes it with DryRun. Objects WooCommerce rejects fail
+// with a BatchError.
+func ReconcileWebhooks(svc WebhookService, specs []WebhookSpec, options WebhookReconcileOption) (*WebhookPlan, error) {
+	current, err := ListAllWebhooks(svc)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanWebhooks(current, specs, options.Prune)
+	if err != nil || options.DryRun || plan.Empty() {
+		return plan, err
+	}
+
+	data := WebhookBatchOption{Create: plan.Create}
+	for _, c := range plan.Update {
+		data.Update = append(data.Update, c.Desired)
+	}
+	for _, w := range plan.Delete {
+		data.Delete = append(data.Delete, w.ID)
+	}
+	result, err := svc.BatchAll(data, nil)
+	if err != nil {
+		return plan, err
+	}
+	return plan, result.Err()
+}
+
+// ListAllWebhooks lists the webhooks of every status, following the pages
+func ListAllWebhooks(svc WebhookService) ([]Webhook, error) {
+	webhooks := make([]Webhook, 0)
+	for page := 1; ; page++ {
+		options := WebhookListOption{
+			ListOptions: ListOptions{Page: page, PerPage: maxPerPage},
+			Status:      "all",
+		}
+		list, err := svc.List(options)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, list...)
+		if len(list) < maxPerPage {
+			return webhooks, nil
+		}
+	}
+}
+
+// webhook returns the webhook to create for the spec
+func (s WebhookSpec) webhook() Webhook {
+	w := Webhook{
+		Name:        s.Name,
+		Topic:       s.Topic,
+		DeliveryUrl: s.DeliveryUrl,
+		Secret:      s.Secret,
+		Status:      s.Status,
+	}
+	if w.Status == "" {
+		w.Status = WebhookStatusActive
+	}
+	if w.Name == "" {
+		w.Name = s.Topic
+	}
+	return w
+}
+
+// webhookDiff lists the properties of current that differ from want
+func webhookDiff(current, want Webhook) []string {
+	fields := make([]string, 0)
+	if current.Name != want.Name {
+		fields = append(fields, "name")
+	}
+	if current.Status != want.Status {
+		fields = append(fields, "status")
+	}
+	if want.Secret != "" && current.Secret != want.Secret {
+		fields = append(fields, "secret")
+	}
+	return fields
+}
+
+func webhookKey(topic, deliveryUrl string) string {
+	return topic + " " + strings.TrimRight(deliveryUrl, "/")
+}
@@ -113,3 +113,119 @@ func TestWebhookServiceOp_Batch(t *testing.T) {
 		t.Logf(" webhook id: %v, webhook status : %v", webhook.ID, webhook.Status)
 	}
 }
+
+func TestPlanWebhooks(t *testing.T) {
+	current := []Webhook{
+		{ID: 1, Topic: "order.created", DeliveryUrl: "https://hooks.example.com/orders", Status: WebhookStatusDisabled, Name: "orders"},
+		{ID: 2, Topic: "order.created", DeliveryUrl: "https://hooks.example.com/orders/", Status: WebhookStatusActive, Name: "orders"},
+		{ID: 3, Topic: "product.updated", DeliveryUrl: "https://hooks.example.com/products", Status: WebhookStatusActive, Name: "products"},
+		{ID: 4, Topic: "coupon.created", DeliveryUrl: "https://old.example.com", Status: WebhookStatusActive},
+	}
+	specs := []WebhookSpec{
+		{Name: "orders", Topic: "order.created", DeliveryUrl: "https://hooks.example.com/orders"},
+		{Name: "products", Topic: "product.updated", DeliveryUrl: "https://hooks.example.com/products", Status: WebhookStatusPaused},
+		{Topic: "customer.created", DeliveryUrl: "https://hooks.example.com/customers", Secret: "s3cret"},
+	}
+
+	plan, err := PlanWebhooks(current, specs, true)
+	if err != nil {
+		t.Fatalf("plan fail: %v", err)
+	}
+	if len(plan.Create) != 1 || plan.Create[0].Topic != "customer.created" || plan.Create[0].Status != WebhookStatusActive {
+		t.Errorf("unexpected creates: %+v", plan.Create)
+	}
+	if len(plan.Update) != 1 || plan.Update[0].Desired.ID != 3 || plan.Update[0].Fields[0] != "status" {
+		t.Errorf("unexpected updates: %+v", plan.Update)
+	}
+	if len(plan.Delete) != 2 || plan.Delete[0].ID != 1 || plan.Delete[1].ID != 4 {
+		t.Errorf("unexpected deletes: %+v", plan.Delete)
+	}
+	t.Logf("plan:\n%s", plan)
+
+	if _, err := PlanWebhooks(nil, []WebhookSpec{{Topic: "order.created"}}, false); err == nil {
+		t.Errorf("spec without delivery url accepted")
+	}
+}
+
+// fakeWebhookService is an in-memory WebhookService
+type fakeWebhookService struct {
+	WebhookService
+	webhooks []Webhook
+	updates  []Webhook
+}
+
+func (f *fakeWebhookService) List(options interface{}) ([]Webhook, error) {
+	return f.webhooks, nil
+}
+
+func (f *fakeWebhookService) Update(webhook *Webhook) (*Webhook, error) {
+	f.updates = append(f.updates, *webhook)
+	return webhook, nil
+}
+
+func TestWebhookMonitor_Check(t *testing.T) {
+	svc := &fakeWebhookService{webhooks: []Webhook{
+		{ID: 1, Topic: "order.created", Status: WebhookStatusActive},
+		{ID: 2, Topic: "order.updated", Status: WebhookStatusDisabled},
+	}}
+	var alerts []WebhookAlert
+	monitor := NewWebhookMonitor(svc,
+		WithMonitorAlert(func(alert WebhookAlert) { alerts = append(alerts, alert) }),
+		WithMonitorReactivation(time.Minute, 10*time.Minute))
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	monitor.now = func() time.Time { return now }
+
+	monitor.Check()
+	if len(alerts) != 1 || alerts[0].Webhook.ID != 2 || !alerts[0].Reactivated {
+		t.Fatalf("unexpected alerts: %+v", alerts)
+	}
+	if len(svc.updates) != 1 || svc.updates[0].Status != WebhookStatusActive {
+		t.Errorf("webhook not reactivated: %+v", svc.updates)
+	}
+
+	// disabled again within the backoff: reported, not reactivated
+	now = now.Add(30 * time.Second)
+	monitor.Check()
+	if len(svc.updates) != 1 || alerts[1].Reactivated {
+		t.Errorf("reactivated during backoff: %+v", alerts[1])
+	}
+
+	// the second attempt waits twice as long
+	now = now.Add(time.Minute)
+	monitor.Check()
+	if len(svc.updates) != 2 || alerts[2].Attempts != 2 || !alerts[2].NextAttempt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("unexpected second attempt: %+v", alerts[2])
+	}
+}
+
+func TestWebhookMonitor_CheckSpecs(t *testing.T) {
+	svc := &fakeWebhookService{webhooks: []Webhook{
+		{ID: 1, Topic: "order.created", DeliveryUrl: "https://example.com/hook", Status: WebhookStatusPaused},
+		{ID: 2, Topic: "order.updated", DeliveryUrl: "https://example.com/hook", Status: WebhookStatusPaused},
+	}}
+	var monitor *WebhookMonitor
+	monitor = NewWebhookMonitor(svc,
+		WithMonitorSpecs([]WebhookSpec{
+			{Topic: "order.created", DeliveryUrl: "https://example.com/hook/", Status: WebhookStatusPaused},
+			{Topic: "order.updated", DeliveryUrl: "https://example.com/hook", Status: WebhookStatusActive},
+		}),
+		WithMonitorAlert(func(alert WebhookAlert) {
+			if !monitor.mu.TryLock() {
+				t.Error("alert called with the monitor locked")
+				return
+			}
+			monitor.mu.Unlock()
+		}),
+		WithMonitorReactivation(0, 0))
+
+	alerts, err := monitor.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 || alerts[0].Webhook.ID != 2 {
+		t.Errorf("alerts = %+v, want webhook 2 only", alerts)
+	}
+	if len(svc.updates) != 1 || svc.updates[0].ID != 2 {
+		t.Errorf("updates = %+v, want webhook 2 reactivated", svc.updates)
+	}
+}
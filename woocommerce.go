@@ -2,6 +2,7 @@ package woocommerce
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,8 @@ const (
 	defaultHttpTimeout   = 30
 	defaultApiPathPrefix = "/wp-json/wc/v3"
 	defaultVersion       = "v3"
+	analyticsPathPrefix  = "/wp-json/wc-analytics"
+	wordpressPathPrefix  = "/wp-json/wp/v2"
 )
 
 var (
@@ -54,19 +57,33 @@ type Client struct {
 	pathPrefix string
 	token      string
 
+	// WordPress user and application password, needed by the wp/v2 endpoints (e.g. media)
+	// which don't accept WooCommerce API keys. See WithWordPressAuth option
+	wpUser     string
+	wpPassword string
+
+	// client signing requests with OAuth1, used instead of Client for stores without https.
+	// Built once by NewClient as requests are sent concurrently, e.g. by the BatchAll methods
+	oauthClient *http.Client
+
+	// allowed order status transitions, see WithOrderStatus option
+	orderStatuses OrderStatusTransitions
+
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
-	attempts int
+	retries int
 
 	RateLimits       RateLimitInfo
 	Product          ProductService
 	ProductVariation ProductVariationService
 	Customer         CustomerService
+	Coupon           CouponService
 	Order            OrderService
 	OrderNote        OrderNoteService
 	Webhook          WebhookService
 	PaymentGateway   PaymentGatewayService
 	Report           ReportService
+	Analytics        AnalyticsService
+	Media            MediaService
 }
 
 // NewClient returns a new WooCommerce API client with an already authenticated shopname and
@@ -94,19 +111,30 @@ func NewClient(app App, shopName string, opts ...Option) *Client {
 		baseURL:    baseURL,
 		version:    defaultVersion,
 		pathPrefix: defaultApiPathPrefix,
+
+		orderStatuses: DefaultOrderStatusTransitions(),
 	}
 
 	c.Product = &ProductServiceOp{client: c}
 	c.ProductVariation = &ProductVariationServiceOp{client: c}
 	c.Customer = &CustomerServiceOp{client: c}
+	c.Coupon = &CouponServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
 	c.OrderNote = &OrderNoteServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
 	c.PaymentGateway = &PaymentGatewayServiceOp{client: c}
 	c.Report = &ReportServiceOp{client: c}
+	c.Analytics = &AnalyticsServiceOp{client: c}
+	c.Media = &MediaServiceOp{client: c}
 	for _, opt := range opts {
 		opt(c)
 	}
+	if baseURL.Scheme != "https" {
+		config := oauth1.NewConfig(c.app.CustomerKey, c.app.CustomerSecret)
+		ctx := context.WithValue(oauth1.NoContext, oauth1.HTTPClient, c.Client)
+		c.oauthClient = config.Client(ctx, oauth1.NewToken("", ""))
+		c.oauthClient.Timeout = c.Client.Timeout
+	}
 
 	return c
 }
@@ -130,38 +158,61 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 
 // doGetHeaders executes a request, decoding the response into `v` and also returns any response headers.
 func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
-	var resp *http.Response
-	var err error
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	retries := c.retries
-	c.attempts = 0
+	if v != nil {
+		decoder := json.NewDecoder(resp.Body)
+		err := decoder.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Header, nil
+}
+
+// send executes a request with retries, returning the successful response with its body to close.
+// Error responses are returned with the error, their body already read but readable again.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
 	c.logRequest(req)
+	httpClient := c.Client
 	// Check if the scheme is "https"
 	if req.URL.Scheme == "https" {
 		q := req.URL.Query()
 		q.Set("consumer_key", c.app.CustomerKey)
 		q.Set("consumer_secret", c.app.CustomerSecret)
 		req.URL.RawQuery = q.Encode()
-		//fmt.Println("The URL is HTTPS")
-	} else {
-		// Create a new OAuth1 configuration
-		config := oauth1.NewConfig(c.app.CustomerKey, c.app.CustomerSecret)
-		token := oauth1.NewToken("", "")
-
-		// Create an OAuth1 HTTP client
-		c.Client = config.Client(oauth1.NoContext, token)
-		fmt.Println("The URL is not HTTPS", req.URL.Scheme)
+	} else if c.oauthClient != nil {
+		// sign the request with OAuth1
+		httpClient = c.oauthClient
 	}
-	for {
-		c.attempts++
+	return c.sendWith(httpClient, req)
+}
+
+// sendWith executes a request already authenticated with httpClient, retrying it as configured
+func (c *Client) sendWith(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
 
-		resp, err = c.Client.Do(req)
+	retries := c.retries
+	for {
+		resp, err = httpClient.Do(req)
 
 		c.logResponse(resp)
 		if err != nil {
 			return nil, err //http client errors, not api responses
 		}
 
+		var body []byte
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			body, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
 		respErr := CheckResponseError(resp)
 		if respErr == nil {
 			break // no errors, break out of the retry loop
@@ -169,9 +220,11 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
+		// keep the body of the error response for the caller
+		resp.Body = io.NopCloser(bytes.NewReader(body))
 
 		if retries <= 1 {
-			return nil, respErr
+			return resp, respErr
 		}
 
 		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr {
@@ -196,21 +249,44 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 
 		//fmt.Println(respErr, "err result", resp)
 		// no retry attempts, just return the err
-		return nil, respErr
+		return resp, respErr
 	}
 
 	c.logResponse(resp)
-	defer resp.Body.Close()
+	return resp, nil
+}
 
-	if v != nil {
-		decoder := json.NewDecoder(resp.Body)
-		err := decoder.Decode(&v)
-		if err != nil {
-			return nil, err
-		}
+// RawResponse is the undecoded response of Client.Raw
+type RawResponse struct {
+	Status int
+	Header http.Header
+	Body   json.RawMessage
+}
+
+// Raw sends a request and returns the response undecoded, for properties and endpoints the
+// package doesn't model yet. relPath is relative to the client's REST namespace, e.g. "orders/17",
+// unless it starts with "/wp-json/". Error responses fail with a ResponseError like the other
+// requests, returned along with the response.
+func (c *Client) Raw(method, relPath string, data, options interface{}) (*RawResponse, error) {
+	prefix := c.pathPrefix
+	if strings.HasPrefix(relPath, "/wp-json/") {
+		prefix = "/"
+	}
+	req, err := c.NewRequest(method, path.Join(prefix, strings.TrimLeft(relPath, "/")), data, options)
+	if err != nil {
+		return nil, err
 	}
+	resp, err := c.send(req)
+	if resp == nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
-	return resp.Header, nil
+	body, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		return nil, readErr
+	}
+	return &RawResponse{Status: resp.StatusCode, Header: resp.Header, Body: body}, err
 }
 
 // ResponseDecodingError occurs when the response body from WooCommerce could
@@ -255,6 +331,7 @@ func CheckResponseError(r *http.Response) error {
 		} else {
 			return ResponseError{
 				Status:  r.StatusCode,
+				Code:    woocommerceError.Code,
 				Message: woocommerceError.Message,
 			}
 		}
@@ -339,6 +416,7 @@ func (c *Client) logBody(body *io.ReadCloser, format string) {
 // https://woocommerce.github.io/woocommerce-rest-api-docs/#request-response-format
 type ResponseError struct {
 	Status  int
+	Code    string
 	Message string
 	Data    []string
 }
@@ -381,11 +459,17 @@ func (c *Client) CreateAndDo(method, relPath string, data, options, resource int
 
 // createAndDoGetHeaders creates an executes a request while returning the response headers.
 func (c *Client) createAndDoGetHeaders(method, relPath string, data, options, resource interface{}) (http.Header, error) {
+	return c.createAndDoWithPrefix(c.pathPrefix, method, relPath, data, options, resource)
+}
+
+// createAndDoWithPrefix creates and executes a request against the given REST namespace
+// (e.g. "/wp-json/wc-analytics") instead of the client's default one.
+func (c *Client) createAndDoWithPrefix(prefix, method, relPath string, data, options, resource interface{}) (http.Header, error) {
 	if strings.HasPrefix(relPath, "/") {
 		relPath = strings.TrimLeft(relPath, "/")
 	}
 
-	relPath = path.Join(c.pathPrefix, relPath)
+	relPath = path.Join(prefix, relPath)
 	//println("relPath:", relPath)
 	req, err := c.NewRequest(method, relPath, data, options)
 	if err != nil {
@@ -473,10 +557,10 @@ type ListOptions struct {
 	Page    int     `url:"page,omitempty"`
 	PerPage int     `url:"per_page,omitempty"`
 	Search  string  `url:"search,omitempty"`
-	After   string  `url:"after,omitempty"`
-	Before  string  `url:"before,omitempty"`
-	Exclude []int64 `url:"exclude,omitempty"`
-	Include []int64 `url:"include,omitempty"`
+	After   Time    `url:"after,omitempty"`
+	Before  Time    `url:"before,omitempty"`
+	Exclude []int64 `url:"exclude,omitempty,comma"`
+	Include []int64 `url:"include,omitempty,comma"`
 	Offset  int     `url:"offset,omitempty"`
 	Order   string  `url:"order,omitempty"`
 	Orderby string  `url:"orderby,omitempty"`
@@ -499,6 +583,26 @@ type Pagination struct {
 	PreviousPageOptions *ListOptions
 	FirstPageOptions    *ListOptions
 	LastPageOptions     *ListOptions
+	// Total and TotalPages are the X-WP-Total and X-WP-TotalPages headers, -1 when missing
+	Total      int
+	TotalPages int
+}
+
+// extractPaginationHeaders extracts pagination info from the Link, X-WP-Total and
+// X-WP-TotalPages response headers
+func extractPaginationHeaders(headers http.Header) (*Pagination, error) {
+	pagination, err := extractPagination(headers.Get("Link"))
+	if err != nil {
+		return nil, err
+	}
+	pagination.Total, pagination.TotalPages = -1, -1
+	if total, err := strconv.Atoi(headers.Get("X-WP-Total")); err == nil {
+		pagination.Total = total
+	}
+	if pages, err := strconv.Atoi(headers.Get("X-WP-TotalPages")); err == nil {
+		pagination.TotalPages = pages
+	}
+	return pagination, nil
 }
 
 // extractPagination extracts pagination info from linkHeader.
@@ -516,11 +620,10 @@ func extractPagination(linkHeader string) (*Pagination, error) {
 	for _, link := range strings.Split(linkHeader, ",") {
 		match := linkRegex.FindStringSubmatch(link)
 		// Make sure the link is not empty or invalid
-		println("mm", len(match))
-		if len(match) != 4 {
+		if len(match) != 3 {
 			// We expect 3 values:
 			// match[0] = full match
-			// match[1] is the URL and match[2] is either 'previous' or 'next', 'first', 'last'
+			// match[1] is the URL and match[2] is either 'prev' or 'next', 'first', 'last'
 			err := ResponseDecodingError{
 				Message: "could not extract pagination link header",
 			}
@@ -565,3 +668,62 @@ func extractPagination(linkHeader string) (*Pagination, error) {
 
 	return pagination, nil
 }
+
+// maxPerPage is the largest page size the REST API accepts
+const maxPerPage = 100
+
+// ListOptionError is returned when list options are rejected before sending the request
+type ListOptionError struct {
+	Field   string
+	Message string
+}
+
+func (e ListOptionError) Error() string {
+	return fmt.Sprintf("woocommerce: invalid %s option: %s", e.Field, e.Message)
+}
+
+// Validate checks the options against the limits of the REST API
+func (o ListOptions) Validate() error {
+	switch {
+	case o.PerPage < 0 || o.PerPage > maxPerPage:
+		return ListOptionError{Field: "per_page", Message: fmt.Sprintf("%d is not between 1 and %d", o.PerPage, maxPerPage)}
+	case o.Page < 0:
+		return ListOptionError{Field: "page", Message: "must not be negative"}
+	case o.Offset < 0:
+		return ListOptionError{Field: "offset", Message: "must not be negative"}
+	case o.Context != "" && o.Context != "view" && o.Context != "edit":
+		return ListOptionError{Field: "context", Message: fmt.Sprintf("%q is not view or edit", o.Context)}
+	case o.Order != "" && o.Order != "asc" && o.Order != "desc":
+		return ListOptionError{Field: "order", Message: fmt.Sprintf("%q is not asc or desc", o.Order)}
+	case o.Orderby == "include" && len(o.Include) == 0:
+		return ListOptionError{Field: "orderby", Message: "include needs include ids"}
+	case !o.After.IsZero() && !o.Before.IsZero() && !o.After.Before(o.Before.Time):
+		return ListOptionError{Field: "after", Message: "must be before the before date"}
+	}
+	if id, ok := overlap(o.Include, o.Exclude); ok {
+		return ListOptionError{Field: "exclude", Message: fmt.Sprintf("id %d is also included", id)}
+	}
+	return nil
+}
+
+// overlap returns an id present in both lists
+func overlap(a, b []int64) (int64, bool) {
+	ids := make(map[int64]bool, len(a))
+	for _, id := range a {
+		ids[id] = true
+	}
+	for _, id := range b {
+		if ids[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// validateListOptions validates options implementing Validate() error, as a value or pointer
+func validateListOptions(options interface{}) error {
+	if v, ok := options.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package woocommerce
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient returns a client talking to a local TLS server serving handler.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient(App{CustomerKey: customerKey, CustomerSecret: customerSecret}, srv.URL)
+	c.Client = srv.Client()
+	return c
+}